@@ -2,6 +2,7 @@ package client
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -9,6 +10,31 @@ import (
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/k8s"
 )
 
+// environment is the Kubernetes cluster the instances are managed in
+var environment dbaas.Environment
+
+// version is the operator version used by the instances
+var version string
+
+// SetEnvironment sets the environment name, kubeconfig context and namespace used by the instances
+func SetEnvironment(name, kubeContext, namespace string) {
+	environment = dbaas.Environment{
+		Name:        name,
+		KubeContext: kubeContext,
+		Namespace:   namespace,
+	}
+}
+
+// Environment returns the environment used by the instances
+func Environment() dbaas.Environment {
+	return environment
+}
+
+// SetVersion sets the operator version used by the instances, the latest supported version is used if it is empty
+func SetVersion(v string) {
+	version = v
+}
+
 func GetInstance(name, options, engine, provider, rootPass string) dbaas.Instance {
 	return dbaas.Instance{
 		Name:          name,
@@ -16,6 +42,8 @@ func GetInstance(name, options, engine, provider, rootPass string) dbaas.Instanc
 		Engine:        engine,
 		Provider:      provider,
 		RootPass:      rootPass,
+		Version:       version,
+		Env:           environment,
 	}
 }
 
@@ -54,3 +82,106 @@ func GetDB(instance dbaas.Instance, hidePass, noWait bool, maxTries int) (dbaas.
 
 	return cluster, errors.New("cluster status: " + string(cluster.Status))
 }
+
+// WaitVolumesResize waits until every data volume of the instance is resized or waits for the filesystem resize.
+// It returns the volumes resize state
+func WaitVolumesResize(instance dbaas.Instance, maxTries int) ([]dbaas.VolumeResize, error) {
+	var volumes []dbaas.VolumeResize
+	tries := 0
+	tckr := time.NewTicker(500 * time.Millisecond)
+	defer tckr.Stop()
+	for range tckr.C {
+		var err error
+		volumes, err = dbaas.VolumesResizeState(instance)
+		if err != nil {
+			return volumes, err
+		}
+		resizing := false
+		for _, v := range volumes {
+			if v.State == dbaas.VolumeResizing {
+				resizing = true
+			}
+		}
+		if !resizing {
+			return volumes, nil
+		}
+
+		if tries >= maxTries {
+			return volumes, errors.New("volumes are still resizing")
+		}
+		tries++
+	}
+
+	return volumes, nil
+}
+
+// WaitMembers waits until the given number of members join the cluster or the replset of the instance.
+// Errors of the members check are ignored while the pods are restarting
+func WaitMembers(instance dbaas.Instance, replset string, members int32, maxTries int) error {
+	tries := 0
+	tckr := time.NewTicker(500 * time.Millisecond)
+	defer tckr.Stop()
+	for range tckr.C {
+		joined, err := dbaas.ClusterMembers(instance, replset)
+		if err == nil && joined == members {
+			return nil
+		}
+
+		if tries >= maxTries {
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("%d of %d members joined the cluster", joined, members)
+		}
+		tries++
+	}
+
+	return nil
+}
+
+// FindInstance looks for the DB resource with the given name among all engines and returns instance for it
+func FindInstance(name string) (dbaas.Instance, error) {
+	list, err := dbaas.ListAllDB(environment, false)
+	if err != nil {
+		return dbaas.Instance{}, err
+	}
+	var found []dbaas.Instance
+	for _, db := range list {
+		if db.ResourceName == name {
+			found = append(found, GetInstance(name, "", db.Engine, db.Provider, ""))
+		}
+	}
+	switch len(found) {
+	case 0:
+		return dbaas.Instance{}, errors.New("can't find database " + name)
+	case 1:
+		return found[0], nil
+	default:
+		return dbaas.Instance{}, errors.New("there are several databases with name " + name + ", please specify the engine")
+	}
+}
+
+// FindVolumesInstance looks for the preserved volumes of the deleted DB resource with the given name among all engines
+// and returns instance for it
+func FindVolumesInstance(name string) (dbaas.Instance, error) {
+	volumes, err := dbaas.ListOrphanedVolumes(environment)
+	if err != nil {
+		return dbaas.Instance{}, err
+	}
+	found := make(map[string]dbaas.Instance)
+	for _, v := range volumes {
+		if v.Cluster == name {
+			found[v.Provider+"/"+v.Engine] = GetInstance(name, "", v.Engine, v.Provider, "")
+		}
+	}
+	switch len(found) {
+	case 0:
+		return dbaas.Instance{}, errors.New("can't find preserved volumes of database " + name)
+	case 1:
+		for _, instance := range found {
+			return instance, nil
+		}
+	}
+
+	return dbaas.Instance{}, errors.New("there are preserved volumes of several databases with name " + name + ", please specify the engine")
+}
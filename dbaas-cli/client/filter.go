@@ -0,0 +1,115 @@
+package client
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
+)
+
+// ParseSelector parses label selector given in "key1=value1,key2=value2" format
+func ParseSelector(selector string) (map[string]string, error) {
+	labels := make(map[string]string)
+	if len(strings.TrimSpace(selector)) == 0 {
+		return labels, nil
+	}
+	for _, pair := range strings.Split(selector, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || len(strings.TrimSpace(kv[0])) == 0 {
+			return nil, errors.New("invalid selector '" + pair + "', use 'key=value' format")
+		}
+		labels[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+
+	return labels, nil
+}
+
+// ParseKeyValues parses labels or annotations given as "key=value" pairs. Values may contain commas
+func ParseKeyValues(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]string)
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || len(strings.TrimSpace(kv[0])) == 0 {
+			return nil, errors.New("invalid value '" + pair + "', use 'key=value' format")
+		}
+		m[strings.TrimSpace(kv[0])] = kv[1]
+	}
+
+	return m, nil
+}
+
+// FilterDB returns databases that have all the given labels and the given status.
+// Empty status matches any status
+func FilterDB(list []dbaas.DB, labels map[string]string, status string) []dbaas.DB {
+	var filtered []dbaas.DB
+	for _, db := range list {
+		if len(status) > 0 && string(db.Status) != status {
+			continue
+		}
+		if !matchLabels(db.Labels, labels) {
+			continue
+		}
+		filtered = append(filtered, db)
+	}
+
+	return filtered
+}
+
+// TrimOptionsPrefix removes the prefix added to the user options from the invalid option reported in err
+// and its suggestions, so they are shown the same way as in the options command
+func TrimOptionsPrefix(err error, prefix string) error {
+	if e, ok := errors.Cause(err).(*options.InvalidOptionError); ok {
+		e.TrimPrefix(prefix)
+	}
+
+	return err
+}
+
+// FilterExpired returns databases which expired before now and databases which expire within the grace period after now
+func FilterExpired(list []dbaas.DB, now time.Time, grace time.Duration) (expired, expiring []dbaas.DB) {
+	for _, db := range list {
+		switch {
+		case db.Expires == nil:
+		case !db.Expires.After(now):
+			expired = append(expired, db)
+		case db.Expires.Before(now.Add(grace)):
+			expiring = append(expiring, db)
+		}
+	}
+
+	return expired, expiring
+}
+
+// FilterOptions returns options under the given prefix with the prefix removed from their paths
+// which start with the given filter. The filter is case insensitive
+func FilterOptions(list []options.Option, prefix, filter string) []options.Option {
+	var filtered []options.Option
+	for _, o := range list {
+		if !strings.HasPrefix(o.Path, prefix) {
+			continue
+		}
+		o.Path = strings.TrimPrefix(o.Path, prefix)
+		if !strings.HasPrefix(strings.ToLower(o.Path), strings.ToLower(filter)) {
+			continue
+		}
+		filtered = append(filtered, o)
+	}
+
+	return filtered
+}
+
+func matchLabels(dbLabels, selector map[string]string) bool {
+	for k, v := range selector {
+		if dbLabels[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,146 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/config"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage CLI defaults",
+	Long: `Manages CLI defaults stored in ~/.percona/config.yaml. Every value can be overridden by
+PERCONA_DBAAS_<KEY> environment variable, e.g. PERCONA_DBAAS_NAMESPACE or PERCONA_DBAAS_STORAGE_CLASS.
+Command line flags take precedence over the defaults.`,
+}
+
+// configGetCmd represents the config get command
+var configGetCmd = &cobra.Command{
+	Use:       "get <key>",
+	Short:     "Show config value",
+	Long:      "Shows the value of the given key with environment variables applied.",
+	ValidArgs: config.Keys(),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("you have to specify config key")
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		value, err := config.Current().Get(args[0])
+		if err != nil {
+			log.Error("get config value: ", err)
+			return
+		}
+
+		fmt.Println(value)
+	},
+}
+
+// configSetCmd represents the config set command
+var configSetCmd = &cobra.Command{
+	Use:       "set <key> <value>",
+	Short:     "Set config value",
+	Long:      "Sets the value of the given key in the config file. Empty value resets the key.",
+	ValidArgs: config.Keys(),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return errors.New("you have to specify config key and value")
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		conf, err := config.LoadFile()
+		if err != nil {
+			log.Error("load config: ", err)
+			return
+		}
+		err = conf.Set(args[0], args[1])
+		if err != nil {
+			log.Error("set config value: ", err)
+			return
+		}
+		err = conf.Save()
+		if err != nil {
+			log.Error("save config: ", err)
+			return
+		}
+
+		log.WithField(args[0], args[1]).Info("config is saved")
+	},
+}
+
+// configViewCmd represents the config view command
+var configViewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Show config",
+	Long:  "Shows the config with environment variables applied.",
+	Run: func(cmd *cobra.Command, args []string) {
+		format, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Error("get output flag: ", err)
+			return
+		}
+		switch format {
+		case "json":
+			log.WithField("config", config.Current()).Info("config")
+		default:
+			data, err := yaml.Marshal(config.Current())
+			if err != nil {
+				log.Error("marshal config: ", err)
+				return
+			}
+			fmt.Print(string(data))
+		}
+	},
+}
+
+// applyConfig sets flags of the command and its subcommands which are not set in the command line to the config values
+func applyConfig(cmd *cobra.Command, conf config.Config) {
+	values := map[string]string{
+		"provider":     conf.Provider,
+		"namespace":    conf.Namespace,
+		"env":          conf.Env,
+		"kube-context": conf.KubeContext,
+		"version":      conf.Version,
+		"output":       conf.Output,
+	}
+	set := func(f *pflag.Flag) {
+		if v := values[f.Name]; len(v) > 0 && !f.Changed {
+			f.Value.Set(v)
+		}
+	}
+	cmd.PersistentFlags().VisitAll(set)
+	cmd.Flags().VisitAll(set)
+	for _, c := range cmd.Commands() {
+		applyConfig(c, conf)
+	}
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd, configSetCmd, configViewCmd)
+	rootCmd.AddCommand(configCmd)
+}
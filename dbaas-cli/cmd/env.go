@@ -0,0 +1,172 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/k8s"
+)
+
+// envCmd represents the env command
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Manage environments",
+	Long:  "Manages named environments. Every environment is a kubeconfig saved in ~/.percona/<name>/kubeconfig which can be used with --env flag or made current.",
+}
+
+// envAddCmd represents the env add command
+var envAddCmd = &cobra.Command{
+	Use:   "add <env-name>",
+	Short: "Add environment",
+	Long:  "Saves a copy of the kubeconfig as the environment with the given name.",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("you have to specify env name")
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		kubeconfig := *envKubeconfig
+		if len(kubeconfig) == 0 {
+			kubeconfig = defaultKubeconfig()
+		}
+		err := k8s.AddEnv(args[0], kubeconfig)
+		if err != nil {
+			log.Error("add env: ", err)
+			return
+		}
+
+		log.WithField("env", args[0]).Info("environment is added")
+	},
+}
+
+// envListCmd represents the env list command
+var envListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List environments",
+	Long:  "Lists saved environments, the current one is marked with '*'.",
+	Run: func(cmd *cobra.Command, args []string) {
+		envs, err := k8s.ListEnvs()
+		if err != nil {
+			log.Error("list envs: ", err)
+			return
+		}
+		current, err := k8s.CurrentEnv()
+		if err != nil {
+			log.Error("get current env: ", err)
+			return
+		}
+
+		format, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Error("get output flag: ", err)
+			return
+		}
+		switch format {
+		case "json":
+			log.WithField("envs", envs).WithField("current", current).Info("environments")
+		default:
+			if len(envs) == 0 {
+				fmt.Println("No environments, the default kubeconfig is used")
+				return
+			}
+			for _, e := range envs {
+				mark := " "
+				if e == current {
+					mark = "*"
+				}
+				fmt.Println(mark, e)
+			}
+		}
+	},
+}
+
+// envUseCmd represents the env use command
+var envUseCmd = &cobra.Command{
+	Use:   "use <env-name>",
+	Short: "Make environment current",
+	Long:  "Makes the environment with the given name current. With --default flag the default kubeconfig becomes current.",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 && !*envUseDefault {
+			return errors.New("you have to specify env name")
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		name := ""
+		if !*envUseDefault {
+			name = args[0]
+		}
+		err := k8s.UseEnv(name)
+		if err != nil {
+			log.Error("use env: ", err)
+			return
+		}
+
+		log.WithField("env", name).Info("current environment is changed")
+	},
+}
+
+// envRemoveCmd represents the env remove command
+var envRemoveCmd = &cobra.Command{
+	Use:   "remove <env-name>",
+	Short: "Remove environment",
+	Long:  "Removes the environment with the given name. If it is current the default kubeconfig becomes current.",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("you have to specify env name")
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := k8s.RemoveEnv(args[0])
+		if err != nil {
+			log.Error("remove env: ", err)
+			return
+		}
+
+		log.WithField("env", args[0]).Info("environment is removed")
+	},
+}
+
+// defaultKubeconfig returns the kubeconfig kubectl uses by default
+func defaultKubeconfig() string {
+	if kubeconfig := filepath.SplitList(os.Getenv("KUBECONFIG")); len(kubeconfig) > 0 && len(kubeconfig[0]) > 0 {
+		return kubeconfig[0]
+	}
+
+	return filepath.Join(os.Getenv("HOME"), ".kube", "config")
+}
+
+var envKubeconfig *string
+var envUseDefault *bool
+
+func init() {
+	envKubeconfig = envAddCmd.Flags().String("kubeconfig", "", "Kubeconfig file to save, the default kubeconfig is used if it is empty")
+	envUseDefault = envUseCmd.Flags().Bool("default", false, "Use the default kubeconfig")
+
+	envCmd.AddCommand(envAddCmd, envListCmd, envUseCmd, envRemoveCmd)
+	rootCmd.AddCommand(envCmd)
+}
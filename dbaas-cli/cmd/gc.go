@@ -0,0 +1,106 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// gcCmd represents the gc command
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete expired clusters",
+	Long:  "Deletes the database clusters of all engines created with the '--ttl' flag which have expired, and warns about the clusters which expire within the grace period. It doesn't ask for confirmation, so it can be run by a CronJob. Clusters protected from deletion are skipped with a warning. The exit code is non-zero if any other expired cluster isn't deleted.",
+	Run: func(cmd *cobra.Command, args []string) {
+		list, err := dbaas.ListAllDB(client.Environment(), false)
+		if err != nil {
+			log.Error("list databases: ", err)
+			os.Exit(1)
+		}
+		expired, expiring := client.FilterExpired(list, time.Now(), *gcGrace)
+		for _, db := range expiring {
+			log.WithField("database", db.ResourceName).WithField("engine", db.Engine).WithField("expires", db.Expires).
+				Warnf("database %s expires %s, extend it with 'modify-db %s --ttl'", db.ResourceName, db.ExpiresIn(), db.ResourceName)
+		}
+
+		failed := 0
+		for _, db := range expired {
+			instance := client.GetInstance(db.ResourceName, "", db.Engine, db.Provider, "")
+			// protected databases are skipped before the final backup is taken
+			protected, err := dbaas.DeletionProtected(instance)
+			if err != nil {
+				log.Errorf("check deletion protection of %s: %v", db.ResourceName, err)
+				failed++
+				continue
+			}
+			if protected {
+				log.WithField("database", db.ResourceName).WithField("engine", db.Engine).
+					Warnf("expired database %s is protected from deletion, it is skipped", db.ResourceName)
+				continue
+			}
+			if *gcDryRun {
+				log.WithField("database", db.ResourceName).WithField("engine", db.Engine).Info("expired database would be deleted")
+				continue
+			}
+			err = gcDelete(instance)
+			if err != nil {
+				log.Errorf("delete expired database %s: %v", db.ResourceName, err)
+				failed++
+				continue
+			}
+			log.WithField("database", db.ResourceName).WithField("engine", db.Engine).Info("expired database is deleted")
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var gcGrace *time.Duration
+var gcDryRun *bool
+var gcFinalBackup *bool
+var gcBackupStorage *string
+var gcPreserve *bool
+
+func init() {
+	gcGrace = gcCmd.Flags().Duration("grace", 24*time.Hour, "Warn about databases which expire within the given time")
+	gcDryRun = gcCmd.Flags().Bool("dry-run", false, "Only show expired databases without deleting them")
+	gcFinalBackup = gcCmd.Flags().Bool("final-backup", false, "Take a backup of every expired database and delete it only if the backup succeeds")
+	gcBackupStorage = gcCmd.Flags().String("backup-storage", "", "Backup storage of the final backups. It may be empty if clusters have only one storage")
+	gcPreserve = gcCmd.Flags().Bool("preserve-data", false, "Do not delete data volumes of expired databases")
+
+	rootCmd.AddCommand(gcCmd)
+}
+
+// gcDelete deletes the expired database taking the final backup first if it is requested
+func gcDelete(instance dbaas.Instance) error {
+	if *gcFinalBackup {
+		backup, err := dbaas.BackupDB(instance, *gcBackupStorage)
+		if err != nil {
+			return err
+		}
+		log.WithField("backup", backup).Info("final backup of " + instance.Name + " is stored in " + backup.Destination + ", secret " + backup.Secret + " with the users it is restored with is kept")
+	}
+	_, err := dbaas.DeleteDB(instance, !*gcPreserve)
+
+	return err
+}
@@ -0,0 +1,75 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	op "github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/output"
+	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List database clusters of all engines",
+	Long:  "Lists database instances or clusters of every supported engine in one table.",
+	Run: func(cmd *cobra.Command, args []string) {
+		labels, err := client.ParseSelector(*listSelector)
+		if err != nil {
+			log.Error("parse selector: ", err)
+			return
+		}
+
+		listDB, err := dbaas.ListAllDB(client.Environment(), *listAllNamespaces)
+		if err != nil {
+			log.Error("list db: ", err)
+			return
+		}
+		listDB = client.FilterDB(listDB, labels, *listStatus)
+		if len(listDB) == 0 {
+			log.Println("Nothing to show")
+			return
+		}
+
+		format, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Error("get output flag: ", err)
+			return
+		}
+		switch format {
+		case "json":
+			log.WithField("database-list", listDB).Info("information")
+		default:
+			op.PrintDBList(os.Stdout, listDB, true)
+		}
+	},
+}
+
+var listAllNamespaces *bool
+var listSelector *string
+var listStatus *string
+
+func init() {
+	listAllNamespaces = listCmd.Flags().Bool("all-namespaces", false, "List clusters in all namespaces")
+	listSelector = listCmd.Flags().StringP("selector", "l", "", "Show only clusters with the given labels in 'key1=value1,key2=value2' format")
+	listStatus = listCmd.Flags().String("status", "", "Show only clusters with the given status (ready, initializing, error, unknown)")
+
+	rootCmd.AddCommand(listCmd)
+}
@@ -18,10 +18,14 @@ import (
 	"fmt"
 	"os"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/cmd/mongo"
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/cmd/mysql"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/config"
+	op "github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/output"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -30,13 +34,39 @@ var rootCmd = &cobra.Command{
 	Short: "The simplest DBaaS tool in the world",
 	Long: `    Hello, it is the simplest DBaaS tool in the world,
 	please use commands below to manage your DBaaS.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Error("get output flag value: ", err)
+			return
+		}
+		log.SetFormatter(op.GetFormatter(output))
+	},
 }
 
+var env *string
+var kubeContext *string
+var namespace *string
+
 func init() {
 	rootCmd.PersistentFlags().StringP("output", "o", "text", `Answers format. Can be "json" or "text".`)
 	rootCmd.AddCommand(mysql.PXCCmd)
 	rootCmd.AddCommand(mongo.MongoCmd)
 	rootCmd.PersistentFlags().Bool("no-wait", false, "Dont wait while command is done")
+	env = rootCmd.PersistentFlags().String("env", "", "Environment to work with, see 'env' command. The current environment is used if it is empty")
+	kubeContext = rootCmd.PersistentFlags().String("kube-context", "", "Kubeconfig context to use")
+	namespace = rootCmd.PersistentFlags().String("namespace", "", "Kubernetes namespace, the kubeconfig context namespace is used if it is empty")
+
+	// subcommands override PersistentPreRun so the config and environment are set on initialization
+	cobra.OnInitialize(func() {
+		conf, err := config.Load()
+		if err != nil {
+			log.Error("load config: ", err)
+		}
+		applyConfig(rootCmd, conf)
+		client.SetEnvironment(*env, *kubeContext, *namespace)
+		client.SetVersion(conf.Version)
+	})
 }
 
 func main() {
@@ -0,0 +1,77 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
+	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// cloneCmd represents the clone-db command
+var cloneCmd = &cobra.Command{
+	Use:               "clone-db <source-cluster-name> <target-cluster-name>",
+	Short:             "Clone MongoDB cluster",
+	Long:              "Creates a new cluster with the spec and users of the source cluster and restores a fresh backup of the source into it. The source should have a backup storage. The command waits until the restore is finished, --no-wait flag is ignored.",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completion.ClusterNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		source := client.GetInstance(args[0], "", *cloneEngine, *cloneProvider, "")
+		target := client.GetInstance(args[1], "", *cloneEngine, *cloneProvider, "")
+
+		dotPrinter.Start("Cloning")
+		backup, err := dbaas.CloneDB(source, target.Name, dbaas.CloneOptions{
+			LatestBackup: *cloneLatestBackup,
+			Storage:      *cloneStorage,
+		})
+		if err != nil {
+			dotPrinter.Stop("error")
+			log.Error("clone db: ", err)
+			return
+		}
+		cluster, err := client.GetDB(target, false, false, maxTries)
+		if err != nil {
+			dotPrinter.Stop("error")
+			log.Errorf("unable to start cluster: %v", err)
+			return
+		}
+		err = dbaas.RestoreDB(target, backup)
+		if err != nil {
+			dotPrinter.Stop("error")
+			log.Errorf("restore backup %s: %v", backup, err)
+			return
+		}
+
+		dotPrinter.Stop("done")
+		log.WithField("database", cluster).Info("Database cloned successfully, connection details are below:")
+	},
+}
+
+var cloneProvider *string
+var cloneEngine *string
+var cloneLatestBackup *bool
+var cloneStorage *string
+
+func init() {
+	cloneProvider = cloneCmd.Flags().String("provider", "k8s", "Provider")
+	cloneEngine = cloneCmd.Flags().String("engine", "psmdb", "Engine")
+	cloneLatestBackup = cloneCmd.Flags().Bool("latest-backup", false, "Restore the latest succeeded backup of the source instead of taking a fresh one")
+	cloneStorage = cloneCmd.Flags().String("storage", "", "Backup storage of the fresh backup. It may be omitted if the source has only one storage")
+
+	MongoCmd.AddCommand(cloneCmd)
+}
@@ -15,11 +15,15 @@
 package mongo
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/config"
 	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
 	_ "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/engines/k8s-psmdb"
 )
@@ -41,14 +45,62 @@ var createCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		instance := client.GetInstance(args[0], addSpec(*options), *engine, *provider, *rootPass)
+		specOpt, err := specFileOption(*specFile)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		conf := config.Current()
+		backupOpt, err := conf.BackupStorageOption()
+		if err != nil {
+			log.Error("config options: ", err)
+			return
+		}
+		planName := *plan
+		if len(planName) == 0 {
+			planName = conf.Plan
+		}
+		defaults, err := conf.ClusterDefaults(planName, true)
+		if err != nil {
+			log.Error("plan: ", err)
+			return
+		}
+		instance := client.GetInstance(args[0], joinOptions(backupOpt, specOpt, addSpec(*options)), *engine, *provider, *rootPass)
+		instance.Defaults = defaults
+		instance.ClusterSize = *size
+		instance.DiskSize = *storageSize
+		instance.StorageClass = *storageClass
+		instance.CPU = *cpu
+		instance.Memory = *memory
+		instance.Expose = *expose
+		instance.TLS, err = dbaas.ParseTLS(*tlsMode)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		instance.Encryption = dbaas.Encryption{Enabled: *encryption, KeySecret: *encryptionKeySecret}
+		instance.PMMServer = *pmmServer
+		instance.PMMUser = *pmmUser
+		instance.PMMPassword = *pmmPassword
+		instance.TTL = *ttl
+		instance.Labels, err = client.ParseKeyValues(*labels)
+		if err != nil {
+			log.Error("parse labels: ", err)
+			return
+		}
+		instance.Annotations, err = client.ParseKeyValues(*annotations)
+		if err != nil {
+			log.Error("parse annotations: ", err)
+			return
+		}
+		instance.DeletionProtection = deletionProtection
 
 		warns, err := dbaas.PreCheck(instance)
 		for _, w := range warns {
 			log.Println("Warning:", w)
 		}
 		if err != nil {
-			log.Error(err)
+			log.Error(client.TrimOptionsPrefix(err, "spec."))
 			return
 		}
 
@@ -56,7 +108,7 @@ var createCmd = &cobra.Command{
 		err = dbaas.CreateDB(instance)
 		if err != nil {
 			dotPrinter.Stop("error")
-			log.Error("create db: ", err)
+			log.Error("create db: ", client.TrimOptionsPrefix(err, "spec."))
 			return
 		}
 		cluster, err := client.GetDB(instance, false, noWait, maxTries)
@@ -81,12 +133,53 @@ var options *string
 var provider *string
 var engine *string
 var rootPass *string
+var plan *string
+var size *int
+var storageSize *string
+var storageClass *string
+var cpu *string
+var memory *string
+var expose *string
+var tlsMode *string
+var encryption *bool
+var encryptionKeySecret *string
+var pmmServer *string
+var pmmUser *string
+var pmmPassword *string
+var ttl *time.Duration
+var labels *[]string
+var annotations *[]string
+var deletionProtection *bool
+var specFile *string
 
 func init() {
 	options = createCmd.Flags().String("options", "", "Engine options in 'p1.p2=text' format. For k8s/psmdb use params from https://www.percona.com/doc/kubernetes-operator-for-psmongodb/operator.html")
 	provider = createCmd.Flags().String("provider", "k8s", "Provider")
 	engine = createCmd.Flags().String("engine", "psmdb", "Engine")
 	rootPass = createCmd.Flags().String("password", "", "Password for superuser")
+	specFile = createCmd.Flags().String("spec-file", "", "YAML or JSON file with the cluster spec, e.g. several replsets with their own size, storage, resources and arbiter. Applied before --options")
+
+	plan = createCmd.Flags().String("plan", "", "Sizing plan: small, medium, large, custom or a plan from the config. The plan from the config is used if it is empty")
+	size = createCmd.Flags().Int("size", 0, "Number of database nodes. The plan or engine default is used if it is 0")
+	storageSize = createCmd.Flags().String("storage-size", "", "Volume size of every node, e.g. 10G")
+	storageClass = createCmd.Flags().String("storage-class", "", "Storage class of the volumes")
+	cpu = createCmd.Flags().String("cpu", "", "Requested CPU of every node, e.g. 1 or 600m")
+	memory = createCmd.Flags().String("memory", "", "Requested memory of every node, e.g. 2G")
+	expose = createCmd.Flags().String("expose", "", "Expose the cluster with the service of the given type: loadbalancer, nodeport or clusterip")
+	tlsMode = createCmd.Flags().String("tls", "", "TLS certificates: 'generate' for a new CA and certificates, 'issuer=<name>' or 'cluster-issuer=<name>' for cert-manager certificates, 'secret=<name>' for an existing TLS secret. The operator default is used if it is empty")
+	encryption = createCmd.Flags().Bool("encryption", false, "Enable data at rest encryption")
+	encryptionKeySecret = createCmd.Flags().String("encryption-key-secret", "", "Secret with the data at rest encryption key. The operator generates the key if it is empty")
+	pmmServer = createCmd.Flags().String("pmm-server", "", "Enable monitoring with the PMM server on the given host")
+	pmmUser = createCmd.Flags().String("pmm-user", "", "User of the PMM server")
+	pmmPassword = createCmd.Flags().String("pmm-password", "", "Password of the PMM server user, it is stored in the cluster secrets")
+	ttl = createCmd.Flags().Duration("ttl", 0, "Delete the cluster with the gc command after the given time, e.g. 48h. The cluster doesn't expire if it is 0")
+	labels = createCmd.Flags().StringArray("label", nil, "Label of the cluster object and secrets in 'key=value' format. Can be repeated")
+	annotations = createCmd.Flags().StringArray("annotation", nil, "Annotation of the cluster object and secrets in 'key=value' format. Can be repeated")
+	deletionProtection = createCmd.Flags().Bool("deletion-protection", false, "Protect the cluster from deletion until the protection is disabled with modify-db")
+
+	createCmd.RegisterFlagCompletionFunc("options", completion.Options)
+	createCmd.RegisterFlagCompletionFunc("plan", completion.Plans)
+	createCmd.RegisterFlagCompletionFunc("expose", completion.ExposeTypes)
 
 	MongoCmd.AddCommand(createCmd)
 }
@@ -15,14 +15,14 @@
 package mongo
 
 import (
-	"fmt"
 	"os"
-	"text/tabwriter"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
+	op "github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/output"
 	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
 )
 
@@ -49,11 +49,17 @@ var describeCmd = &cobra.Command{
 			return
 		}
 
+		selector, err := client.ParseSelector(*descrSelector)
+		if err != nil {
+			log.Error("parse selector: ", err)
+			return
+		}
 		listDB, err := dbaas.ListDB(instance)
 		if err != nil {
 			log.Error("list db: ", err)
 			return
 		}
+		listDB = client.FilterDB(listDB, selector, "")
 		if len(listDB) == 0 {
 			log.Println("Nothing to show")
 			return
@@ -68,25 +74,20 @@ var describeCmd = &cobra.Command{
 		case "json":
 			log.WithField("database-list", listDB).Info("information")
 		default:
-			w := new(tabwriter.Writer)
-			w.Init(os.Stdout, 0, 8, 0, '\t', 0)
-			fmt.Fprintln(w, "NAME\tSTATUS\t")
-			for _, db := range listDB {
-				fmt.Fprintln(w, fmt.Sprintf("%s\t%s", db.ResourceName, db.Status))
-
-			}
-			fmt.Fprintln(w)
-			w.Flush()
+			op.PrintDBList(os.Stdout, listDB, false)
 		}
 	},
+	ValidArgsFunction: completion.ClusterNames,
 }
 
 var descrProvider *string
 var descrEngine *string
+var descrSelector *string
 
 func init() {
 	descrProvider = describeCmd.Flags().String("provider", "k8s", "Provider")
 	descrEngine = describeCmd.Flags().String("engine", "psmdb", "Engine")
+	descrSelector = describeCmd.Flags().StringP("selector", "l", "", "List only clusters with the given labels in 'key1=value1,key2=value2' format")
 
 	MongoCmd.AddCommand(describeCmd)
 }
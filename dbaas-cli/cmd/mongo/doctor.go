@@ -0,0 +1,73 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"errors"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
+	op "github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/output"
+	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor <mongo-cluster-name>",
+	Short: "Diagnose MongoDB cluster problems",
+	Long:  "Inspects pods, volumes, events, operator logs, cluster quorum and resources of the database cluster with the given name and prints found problems ordered by severity with hints how to fix them.",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("you have to specify resource name")
+		}
+
+		return nil
+	},
+	ValidArgsFunction: completion.ClusterNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		instance := client.GetInstance(args[0], "", *doctorEngine, *doctorProvider, "")
+
+		findings, err := dbaas.Diagnose(instance)
+		if err != nil {
+			log.Error("diagnose db: ", err)
+			return
+		}
+		format, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Error("get output flag: ", err)
+			return
+		}
+		switch format {
+		case "json":
+			log.WithField("findings", findings).Info("diagnostics")
+		default:
+			op.PrintFindings(os.Stdout, findings)
+		}
+	},
+}
+
+var doctorProvider *string
+var doctorEngine *string
+
+func init() {
+	doctorProvider = doctorCmd.Flags().String("provider", "k8s", "Provider")
+	doctorEngine = doctorCmd.Flags().String("engine", "psmdb", "Engine")
+
+	MongoCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,72 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"errors"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
+	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// logsCmd represents the logs command
+var logsCmd = &cobra.Command{
+	Use:   "logs <mongo-cluster-name>",
+	Short: "Show MongoDB cluster logs",
+	Long:  "Shows logs of all pods of the database cluster with the given name or of the chosen component. Each line is prefixed with the pod name.",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("you have to specify resource name")
+		}
+
+		return nil
+	},
+	ValidArgsFunction: completion.ClusterNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		instance := client.GetInstance(args[0], "", *logsEngine, *logsProvider, "")
+
+		opts := dbaas.LogOptions{
+			Component: *logsComponent,
+			Follow:    *logsFollow,
+			Since:     *logsSince,
+		}
+		err := dbaas.StreamLogs(instance, opts, os.Stdout)
+		if err != nil {
+			log.Error("show logs: ", err)
+			return
+		}
+	},
+}
+
+var logsProvider *string
+var logsEngine *string
+var logsComponent *string
+var logsFollow *bool
+var logsSince *string
+
+func init() {
+	logsProvider = logsCmd.Flags().String("provider", "k8s", "Provider")
+	logsEngine = logsCmd.Flags().String("engine", "psmdb", "Engine")
+	logsComponent = logsCmd.Flags().String("component", "", "Show logs only of the given component: mongod, arbiter, backup or operator")
+	logsFollow = logsCmd.Flags().BoolP("follow", "f", false, "Keep streaming new logs")
+	logsSince = logsCmd.Flags().String("since", "", "Show only logs newer than the given duration like 5s, 2m or 3h")
+
+	MongoCmd.AddCommand(logsCmd)
+}
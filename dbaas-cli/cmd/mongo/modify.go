@@ -22,6 +22,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/config"
 	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
 	_ "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/engines/k8s-pxc"
 )
@@ -38,15 +40,48 @@ var modifyCmd = &cobra.Command{
 
 		return nil
 	},
+	ValidArgsFunction: completion.ClusterNames,
 	Run: func(cmd *cobra.Command, args []string) {
-		instance := client.GetInstance(args[0], addSpec(*modifyOptions), *modifyEngine, *modifyProvider, "")
+		specOpt, err := specFileOption(*modifySpecFile)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		defaults, err := config.Current().ClusterDefaults(*modifyPlan, false)
+		if err != nil {
+			log.Error("plan: ", err)
+			return
+		}
+		instance := client.GetInstance(args[0], joinOptions(specOpt, addSpec(*modifyOptions)), *modifyEngine, *modifyProvider, "")
+		instance.Defaults = defaults
+		instance.ClusterSize = *modifySize
+		instance.CPU = *modifyCPU
+		instance.Memory = *modifyMemory
+		instance.Expose = *modifyExpose
+		instance.PMMServer = *modifyPMMServer
+		instance.PMMUser = *modifyPMMUser
+		instance.PMMPassword = *modifyPMMPassword
+		instance.TTL = *modifyTTL
+		instance.Labels, err = client.ParseKeyValues(*modifyLabels)
+		if err != nil {
+			log.Error("parse labels: ", err)
+			return
+		}
+		instance.Annotations, err = client.ParseKeyValues(*modifyAnnotations)
+		if err != nil {
+			log.Error("parse annotations: ", err)
+			return
+		}
+		if cmd.Flags().Changed("deletion-protection") {
+			instance.DeletionProtection = modifyDeletionProtection
+		}
 
 		warns, err := dbaas.PreCheck(instance)
 		for _, w := range warns {
 			log.Println("Warning:", w)
 		}
 		if err != nil {
-			log.Error(err)
+			log.Error(client.TrimOptionsPrefix(err, "spec."))
 			return
 		}
 
@@ -54,7 +89,7 @@ var modifyCmd = &cobra.Command{
 		err = dbaas.ModifyDB(instance)
 		if err != nil {
 			dotPrinter.Stop("error")
-			log.Error("modify db: ", err)
+			log.Error("modify db: ", client.TrimOptionsPrefix(err, "spec."))
 			return
 		}
 		time.Sleep(time.Second * 10) //let k8s time for applying new cr
@@ -80,11 +115,42 @@ var modifyCmd = &cobra.Command{
 var modifyOptions *string
 var modifyProvider *string
 var modifyEngine *string
+var modifyPlan *string
+var modifySize *int
+var modifyCPU *string
+var modifyMemory *string
+var modifyExpose *string
+var modifyPMMServer *string
+var modifyPMMUser *string
+var modifyPMMPassword *string
+var modifyTTL *time.Duration
+var modifyLabels *[]string
+var modifyAnnotations *[]string
+var modifyDeletionProtection *bool
+var modifySpecFile *string
 
 func init() {
 	modifyOptions = modifyCmd.Flags().String("options", "", "Engine options in 'p1.p2=text' format. Use params from https://www.percona.com/doc/kubernetes-operator-for-psmongodb/operator.html")
 	modifyProvider = modifyCmd.Flags().String("provider", "k8s", "Provider")
 	modifyEngine = modifyCmd.Flags().String("engine", "psmdb", "Engine")
+	modifySpecFile = modifyCmd.Flags().String("spec-file", "", "YAML or JSON file with the cluster spec. Applied before --options")
+
+	modifyPlan = modifyCmd.Flags().String("plan", "", "Sizing plan: small, medium, large or a plan from the config. Storage size and class of the plan aren't changed")
+	modifySize = modifyCmd.Flags().Int("size", 0, "Number of database nodes. Not changed if it is 0")
+	modifyCPU = modifyCmd.Flags().String("cpu", "", "Requested CPU of every node, e.g. 1 or 600m")
+	modifyMemory = modifyCmd.Flags().String("memory", "", "Requested memory of every node, e.g. 2G")
+	modifyExpose = modifyCmd.Flags().String("expose", "", "Expose the cluster with the service of the given type: loadbalancer, nodeport or clusterip")
+	modifyPMMServer = modifyCmd.Flags().String("pmm-server", "", "Enable monitoring with the PMM server on the given host")
+	modifyPMMUser = modifyCmd.Flags().String("pmm-user", "", "User of the PMM server")
+	modifyPMMPassword = modifyCmd.Flags().String("pmm-password", "", "Password of the PMM server user, it is stored in the cluster secrets")
+	modifyTTL = modifyCmd.Flags().Duration("ttl", 0, "Set the cluster expiry to the given time from now, e.g. 48h. Not changed if it is 0")
+	modifyLabels = modifyCmd.Flags().StringArray("label", nil, "Label of the cluster object and secrets in 'key=value' format. Can be repeated")
+	modifyAnnotations = modifyCmd.Flags().StringArray("annotation", nil, "Annotation of the cluster object and secrets in 'key=value' format. Can be repeated")
+	modifyDeletionProtection = modifyCmd.Flags().Bool("deletion-protection", false, "Enable or disable the deletion protection of the cluster. Not changed if the flag isn't set")
+
+	modifyCmd.RegisterFlagCompletionFunc("options", completion.Options)
+	modifyCmd.RegisterFlagCompletionFunc("plan", completion.Plans)
+	modifyCmd.RegisterFlagCompletionFunc("expose", completion.ExposeTypes)
 
 	MongoCmd.AddCommand(modifyCmd)
 }
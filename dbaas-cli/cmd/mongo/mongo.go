@@ -15,10 +15,12 @@
 package mongo
 
 import (
+	"path/filepath"
 	"strings"
 
 	op "github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/output"
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/pb"
+	engineopts "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -52,8 +54,29 @@ var MongoCmd = &cobra.Command{
 }
 
 func addSpec(opts string) string {
-	if len(opts) == 0 {
-		return ""
+	return engineopts.AddPrefix(opts, "spec.")
+}
+
+func joinOptions(opts ...string) string {
+	var nonEmpty []string
+	for _, o := range opts {
+		if len(o) > 0 {
+			nonEmpty = append(nonEmpty, o)
+		}
 	}
-	return "spec." + strings.Replace(opts, ",", ",spec.", -1)
+
+	return strings.Join(nonEmpty, ",")
+}
+
+// specFileOption returns engine option which sets the whole cluster spec from the given YAML or JSON file
+func specFileOption(path string) (string, error) {
+	if len(path) == 0 {
+		return "", nil
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", errors.Wrap(err, "get spec file path")
+	}
+
+	return "spec=@" + absPath, nil
 }
@@ -0,0 +1,71 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	op "github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/output"
+	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// optionsCmd represents the options command
+var optionsCmd = &cobra.Command{
+	Use:   "options",
+	Short: "List MongoDB cluster options",
+	Long:  "Lists every option which can be set with --options flag of create and modify commands with its type and default value.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		instance := client.GetInstance("", "", *optionsEngine, *optionsProvider, "")
+		instance.Version = *optionsVersion
+
+		list, err := dbaas.ListOptions(instance)
+		if err != nil {
+			log.Error("list options: ", err)
+			return
+		}
+		list = client.FilterOptions(list, "spec.", *optionsFilter)
+
+		format, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Error("get output flag: ", err)
+			return
+		}
+		switch format {
+		case "json":
+			log.WithField("options", list).Info("options")
+		default:
+			op.PrintOptions(os.Stdout, list)
+		}
+	},
+}
+
+var optionsProvider *string
+var optionsEngine *string
+var optionsVersion *string
+var optionsFilter *string
+
+func init() {
+	optionsProvider = optionsCmd.Flags().String("provider", "k8s", "Provider")
+	optionsEngine = optionsCmd.Flags().String("engine", "psmdb", "Engine")
+	optionsVersion = optionsCmd.Flags().String("version", "", "Operator version, the latest supported version is used if it is empty")
+	optionsFilter = optionsCmd.Flags().String("filter", "", "Show only options which start with the given prefix")
+
+	MongoCmd.AddCommand(optionsCmd)
+}
@@ -0,0 +1,106 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
+	op "github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/output"
+	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// resizeStorageCmd represents the resize-storage command
+var resizeStorageCmd = &cobra.Command{
+	Use:   "resize-storage <mongo-cluster-name>",
+	Short: "Resize storage of MongoDB cluster",
+	Long:  "Expands data volumes of the cluster to the given size and sets the size in the cluster. If the storage class doesn't allow volume expansion, shows the steps to replace the volumes one by one.",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("You have to specify resource name")
+		}
+
+		return nil
+	},
+	ValidArgsFunction: completion.ClusterNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		instance := client.GetInstance(args[0], "", *resizeStorageEngine, *resizeStorageProvider, "")
+
+		format, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Error("get output flag: ", err)
+			return
+		}
+
+		res, err := dbaas.ResizeStorage(instance, *resizeStorageSize)
+		if err != nil {
+			log.Error("resize storage: ", err)
+			return
+		}
+		if !res.Expanded {
+			log.Warn(res.Reason + ". The cluster is updated to use the new size for new volumes, replace the existing volumes one by one:")
+			switch format {
+			case "json":
+				log.WithField("steps", res.Steps).Info("volumes replacement")
+			default:
+				op.PrintSteps(os.Stdout, res.Steps)
+			}
+			return
+		}
+		if noWait {
+			log.Info("storage resize is started")
+			return
+		}
+
+		dotPrinter.Start("Resizing")
+		volumes, err := client.WaitVolumesResize(instance, maxTries)
+		if err != nil {
+			dotPrinter.Stop("error")
+			log.Error("wait volumes resize: ", err)
+			return
+		}
+		dotPrinter.Stop("done")
+
+		switch format {
+		case "json":
+			log.WithField("volumes", volumes).Info("storage resized")
+		default:
+			op.PrintVolumesResize(os.Stdout, volumes)
+		}
+		for _, v := range volumes {
+			if v.State == dbaas.VolumeFileSystemResizePending {
+				log.Warn("filesystem resize of some volumes is pending, it is finished when the pod using the volume is restarted")
+				break
+			}
+		}
+	},
+}
+
+var resizeStorageProvider *string
+var resizeStorageEngine *string
+var resizeStorageSize *string
+
+func init() {
+	resizeStorageProvider = resizeStorageCmd.Flags().String("provider", "k8s", "Provider")
+	resizeStorageEngine = resizeStorageCmd.Flags().String("engine", "psmdb", "Engine")
+	resizeStorageSize = resizeStorageCmd.Flags().String("size", "", "New size of every data volume, e.g. 50G")
+
+	MongoCmd.AddCommand(resizeStorageCmd)
+}
@@ -0,0 +1,107 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
+	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// scaleCmd represents the scale-db command
+var scaleCmd = &cobra.Command{
+	Use:   "scale-db <mongo-cluster-name>",
+	Short: "Scale MongoDB cluster",
+	Long:  "Changes the number of members of the replset. The number of members should be odd to keep the quorum. Checks that the Kubernetes nodes have enough resources for the new pods and waits until every new member joins the replset.",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("You have to specify resource name")
+		}
+
+		return nil
+	},
+	ValidArgsFunction: completion.ClusterNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		instance := client.GetInstance(args[0], "", *scaleEngine, *scaleProvider, "")
+		if !*scaleYes {
+			members, err := dbaas.ClusterMembers(instance, *scaleReplset)
+			if err != nil {
+				log.Error("get cluster members: ", err)
+				return
+			}
+			if *scaleMembers < members {
+				var yn string
+				fmt.Printf("ARE YOU SURE YOU WANT TO SCALE THE DATABASE '%s' DOWN FROM %d TO %d MEMBERS? Yes/No\nTHE CLUSTER WILL TOLERATE FEWER FAILURES.\n", args[0], members, *scaleMembers)
+				scanner := bufio.NewScanner(os.Stdin)
+				for scanner.Scan() {
+					yn = strings.TrimSpace(scanner.Text())
+					break
+				}
+				if yn != "yes" && yn != "Yes" && yn != "YES" && yn != "Y" && yn != "y" {
+					return
+				}
+			}
+		}
+
+		warns, err := dbaas.ScaleDB(instance, dbaas.Scale{Nodes: *scaleMembers, Replset: *scaleReplset})
+		for _, w := range warns {
+			log.Println("Warning:", w)
+		}
+		if err != nil {
+			log.Error("scale db: ", err)
+			return
+		}
+		if noWait {
+			log.Info("scaling is started")
+			return
+		}
+
+		dotPrinter.Start("Scaling")
+		err = client.WaitMembers(instance, *scaleReplset, *scaleMembers, maxTries)
+		if err != nil {
+			dotPrinter.Stop("error")
+			log.Error("wait members: ", err)
+			return
+		}
+		dotPrinter.Stop("done")
+		log.WithField("members", *scaleMembers).Info("replset is scaled")
+	},
+}
+
+var scaleProvider *string
+var scaleEngine *string
+var scaleYes *bool
+var scaleReplset *string
+var scaleMembers *int32
+
+func init() {
+	scaleProvider = scaleCmd.Flags().String("provider", "k8s", "Provider")
+	scaleEngine = scaleCmd.Flags().String("engine", "psmdb", "Engine")
+	scaleReplset = scaleCmd.Flags().String("replset", "", "Name of the replset, the first replset is used if it is empty")
+	scaleMembers = scaleCmd.Flags().Int32("members", 0, "Number of the replset members, should be odd")
+	scaleYes = scaleCmd.Flags().BoolP("yes", "y", false, "Answer yes for questions")
+	scaleCmd.MarkFlagRequired("members")
+
+	MongoCmd.AddCommand(scaleCmd)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
 	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
 )
 
@@ -41,8 +42,18 @@ var delCmd = &cobra.Command{
 
 		return nil
 	},
+	ValidArgsFunction: completion.ClusterNames,
 	Run: func(cmd *cobra.Command, args []string) {
 		instance := client.GetInstance(args[0], "", *delEngine, *delProvider, "")
+		protected, err := dbaas.DeletionProtected(instance)
+		if err != nil {
+			log.Error("check deletion protection: ", err)
+			return
+		}
+		if protected {
+			log.Errorf("database %s is protected from deletion, disable the protection with 'modify-db %s --deletion-protection=false' first", args[0], args[0])
+			return
+		}
 
 		if !*forced {
 			var yn string
@@ -50,6 +61,9 @@ var delCmd = &cobra.Command{
 			if !*preserve {
 				preservText = "ALL YOUR DATA WILL BE LOST. USE '--preserve-data' FLAG TO SAVE IT.\n"
 			}
+			if *finalBackup {
+				preservText += "A FINAL BACKUP WILL BE TAKEN BEFORE THE DELETION.\n"
+			}
 			fmt.Printf("ARE YOU SURE YOU WANT TO DELETE THE DATABASE '%s'? Yes/No\n"+preservText, args[0])
 			scanner := bufio.NewScanner(os.Stdin)
 			for scanner.Scan() {
@@ -66,6 +80,19 @@ var delCmd = &cobra.Command{
 			deletePVC = true
 		}
 
+		// the cluster is deleted only after the backup succeeds
+		if *finalBackup {
+			dotPrinter.Start("Taking final backup")
+			backup, err := dbaas.BackupDB(instance, *finalBackupStorage)
+			if err != nil {
+				dotPrinter.Stop("error")
+				log.Error("final backup: ", err)
+				return
+			}
+			dotPrinter.Stop("done")
+			log.WithField("backup", backup).Info("final backup is stored in " + backup.Destination + ", secret " + backup.Secret + " with the users it is restored with is kept")
+		}
+
 		if noWait {
 			go dbaas.DeleteDB(instance, deletePVC)
 			time.Sleep(time.Second * 3)
@@ -102,12 +129,16 @@ var delProvider *string
 var delEngine *string
 var forced *bool
 var preserve *bool
+var finalBackup *bool
+var finalBackupStorage *string
 
 func init() {
 	forced = delCmd.Flags().BoolP("yes", "y", false, "Unswer yes for questions")
 	delProvider = delCmd.Flags().String("provider", "k8s", "Provider")
 	delEngine = delCmd.Flags().String("engine", "pxc", "Engine")
 	preserve = delCmd.Flags().Bool("preserve-data", false, "Do not delete data")
+	finalBackup = delCmd.Flags().Bool("final-backup", false, "Take a backup of the cluster and wait until it succeeds before the deletion. The users secret is kept to restore the backup")
+	finalBackupStorage = delCmd.Flags().String("backup-storage", "", "Backup storage of the final backup. It may be empty if the cluster has only one storage")
 
 	PXCCmd.AddCommand(delCmd)
 }
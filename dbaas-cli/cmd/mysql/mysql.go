@@ -19,6 +19,7 @@ import (
 
 	op "github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/output"
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/pb"
+	engineopts "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -52,8 +53,16 @@ var PXCCmd = &cobra.Command{
 }
 
 func addSpec(opts string) string {
-	if len(opts) == 0 {
-		return ""
+	return engineopts.AddPrefix(opts, "spec.")
+}
+
+func joinOptions(opts ...string) string {
+	var nonEmpty []string
+	for _, o := range opts {
+		if len(o) > 0 {
+			nonEmpty = append(nonEmpty, o)
+		}
 	}
-	return "spec." + strings.Replace(opts, ",", ",spec.", -1)
+
+	return strings.Join(nonEmpty, ",")
 }
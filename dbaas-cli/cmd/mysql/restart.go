@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
 	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
 	_ "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/engines/k8s-pxc"
 )
@@ -38,6 +39,7 @@ var restartCmd = &cobra.Command{
 
 		return nil
 	},
+	ValidArgsFunction: completion.ClusterNames,
 	Run: func(cmd *cobra.Command, args []string) {
 		instance := client.GetInstance(args[0], addSpec("pause=true"), *restartEngine, *restartProvider, "")
 
@@ -107,5 +109,7 @@ func init() {
 	restartProvider = restartCmd.Flags().String("provider", "k8s", "Provider")
 	restartEngine = restartCmd.Flags().String("engine", "pxc", "Engine")
 
+	restartCmd.RegisterFlagCompletionFunc("options", completion.Options)
+
 	PXCCmd.AddCommand(restartCmd)
 }
@@ -0,0 +1,88 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
+	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// supportBundleCmd represents the support-bundle command
+var supportBundleCmd = &cobra.Command{
+	Use:   "support-bundle <cluster-name>",
+	Short: "Collect cluster data for troubleshooting",
+	Long:  "Collects the cluster object, operator and pod logs, events, volume claims and services of the database cluster with the given name into a tar.gz archive with secrets redacted. The archive can be attached to a support ticket.",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("you have to specify resource name")
+		}
+
+		return nil
+	},
+	ValidArgsFunction: completion.ClusterNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		instance := client.GetInstance(args[0], "", *bundleEngine, *bundleProvider, "")
+		if len(instance.Engine) == 0 {
+			var err error
+			instance, err = client.FindInstance(args[0])
+			if err != nil {
+				log.Error("find db: ", err)
+				return
+			}
+		}
+
+		fileName := filepath.Join(*bundleDir, args[0]+"-support-bundle-"+time.Now().Format("20060102-150405")+".tar.gz")
+		f, err := os.Create(fileName)
+		if err != nil {
+			log.Error("create bundle file: ", err)
+			return
+		}
+		err = dbaas.SupportBundle(instance, f)
+		if err != nil {
+			f.Close()
+			os.Remove(fileName)
+			log.Error("collect support bundle: ", err)
+			return
+		}
+		err = f.Close()
+		if err != nil {
+			log.Error("close bundle file: ", err)
+			return
+		}
+
+		log.WithField("file", fileName).Info("support bundle is saved")
+	},
+}
+
+var bundleProvider *string
+var bundleEngine *string
+var bundleDir *string
+
+func init() {
+	bundleProvider = supportBundleCmd.Flags().String("provider", "k8s", "Provider")
+	bundleEngine = supportBundleCmd.Flags().String("engine", "", "Engine (pxc or psmdb). Detected by the cluster name if empty")
+	bundleDir = supportBundleCmd.Flags().String("dir", ".", "Directory to save the bundle to")
+
+	rootCmd.AddCommand(supportBundleCmd)
+}
@@ -0,0 +1,198 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/completion"
+	op "github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/output"
+	dbaas "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+	engineopts "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
+)
+
+// volumesCmd represents the volumes command
+var volumesCmd = &cobra.Command{
+	Use:   "volumes",
+	Short: "Manage data preserved after database deletion",
+	Long:  "Lists, attaches and purges data volumes of the databases deleted with the '--preserve-data' flag.",
+}
+
+// volumesListCmd represents the volumes list command
+var volumesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List preserved data volumes",
+	Long:  "Lists data volumes which belong to deleted databases of all engines.",
+	Run: func(cmd *cobra.Command, args []string) {
+		volumes, err := dbaas.ListOrphanedVolumes(client.Environment())
+		if err != nil {
+			log.Error("list volumes: ", err)
+			return
+		}
+
+		format, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Error("get output flag: ", err)
+			return
+		}
+		switch format {
+		case "json":
+			log.WithField("volumes", volumes).Info("preserved volumes")
+		default:
+			if len(volumes) == 0 {
+				fmt.Println("No preserved volumes")
+				return
+			}
+			op.PrintVolumes(os.Stdout, volumes)
+		}
+	},
+}
+
+// volumesAttachCmd represents the volumes attach command
+var volumesAttachCmd = &cobra.Command{
+	Use:   "attach <cluster-name>",
+	Short: "Create database on top of its preserved data",
+	Long:  "Creates the deleted database with the given name again using its preserved data volumes and secrets. The number of nodes and storage size are taken from the volumes.",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("you have to specify resource name")
+		}
+
+		return nil
+	},
+	ValidArgsFunction: completion.VolumeClusterNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		instance, err := volumesInstance(args[0], *volumesEngine, *volumesProvider)
+		if err != nil {
+			log.Error("find volumes: ", err)
+			return
+		}
+		instance.EngineOptions = engineopts.AddPrefix(*volumesOptions, "spec.")
+
+		format, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Error("get output flag: ", err)
+			return
+		}
+		noWait, err := cmd.Flags().GetBool("no-wait")
+		if err != nil {
+			log.Error("get no-wait flag: ", err)
+			return
+		}
+		dotPrinter := op.GetDotprinter(format)
+
+		dotPrinter.Start("Starting")
+		err = dbaas.AttachVolumes(instance)
+		if err != nil {
+			dotPrinter.Stop("error")
+			log.Error("attach volumes: ", client.TrimOptionsPrefix(err, "spec."))
+			return
+		}
+		cluster, err := client.GetDB(instance, false, noWait, maxTries)
+		if err != nil {
+			dotPrinter.Stop("error")
+			log.Errorf("unable to start cluster: %v", err)
+			return
+		}
+
+		if cluster.Status == dbaas.StateInit {
+			dotPrinter.Stop("initializing")
+			log.WithField("database", cluster).Info("information")
+			return
+		}
+
+		dotPrinter.Stop("done")
+		log.WithField("database", cluster).Info("Database started successfully, connection details are below:")
+	},
+}
+
+// volumesPurgeCmd represents the volumes purge command
+var volumesPurgeCmd = &cobra.Command{
+	Use:   "purge <cluster-name>",
+	Short: "Delete preserved data",
+	Long:  "Deletes the preserved data volumes and secrets of the deleted database with the given name.",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("you have to specify resource name")
+		}
+
+		return nil
+	},
+	ValidArgsFunction: completion.VolumeClusterNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		instance, err := volumesInstance(args[0], *volumesEngine, *volumesProvider)
+		if err != nil {
+			log.Error("find volumes: ", err)
+			return
+		}
+
+		if !*volumesForced {
+			var yn string
+			fmt.Printf("ARE YOU SURE YOU WANT TO PURGE THE DATA OF THE DATABASE '%s'? Yes/No\nALL DATA WILL BE LOST.\n", args[0])
+			scanner := bufio.NewScanner(os.Stdin)
+			for scanner.Scan() {
+				yn = strings.TrimSpace(scanner.Text())
+				break
+			}
+			if yn != "yes" && yn != "Yes" && yn != "YES" && yn != "Y" && yn != "y" {
+				return
+			}
+		}
+
+		err = dbaas.PurgeVolumes(instance)
+		if err != nil {
+			log.Error("purge volumes: ", err)
+			return
+		}
+
+		log.WithField("database", args[0]).Info("preserved data is purged")
+	},
+}
+
+// maxTries is the number of database status checks while waiting for the attached database
+const maxTries = 1200
+
+var volumesProvider *string
+var volumesEngine *string
+var volumesOptions *string
+var volumesForced *bool
+
+func init() {
+	volumesProvider = volumesCmd.PersistentFlags().String("provider", "k8s", "Provider")
+	volumesEngine = volumesCmd.PersistentFlags().String("engine", "", "Engine (pxc or psmdb). Detected by the cluster name if empty")
+	volumesOptions = volumesAttachCmd.Flags().String("options", "", "Engine options of the attached database in 'p1.p2=text' format, the same as create-db options")
+	volumesForced = volumesPurgeCmd.Flags().BoolP("yes", "y", false, "Answer yes for questions")
+
+	volumesCmd.AddCommand(volumesListCmd)
+	volumesCmd.AddCommand(volumesAttachCmd)
+	volumesCmd.AddCommand(volumesPurgeCmd)
+	rootCmd.AddCommand(volumesCmd)
+}
+
+func volumesInstance(name, engine, provider string) (dbaas.Instance, error) {
+	if len(engine) > 0 {
+		return client.GetInstance(name, "", engine, provider, ""), nil
+	}
+
+	return client.FindVolumesInstance(name)
+}
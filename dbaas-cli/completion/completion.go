@@ -0,0 +1,108 @@
+package completion
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/client"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/config"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// ClusterNames completes the first argument with names of the existing clusters
+// of the engine and provider set by the command flags. Clusters of all engines are used if the engine is empty
+func ClusterNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var list []dbaas.DB
+	var err error
+	i := instance(cmd)
+	if len(i.Engine) == 0 {
+		list, err = dbaas.ListAllDB(i.Env, false)
+	} else {
+		list, err = dbaas.ListDB(i)
+	}
+	if err != nil {
+		cobra.CompErrorln("list databases: " + err.Error())
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var names []string
+	for _, db := range list {
+		if strings.HasPrefix(db.ResourceName, toComplete) {
+			names = append(names, db.ResourceName)
+		}
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// VolumeClusterNames completes the first argument with names of the deleted clusters with preserved data
+func VolumeClusterNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	volumes, err := dbaas.ListOrphanedVolumes(instance(cmd).Env)
+	if err != nil {
+		cobra.CompErrorln("list volumes: " + err.Error())
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, v := range volumes {
+		if !seen[v.Cluster] && strings.HasPrefix(v.Cluster, toComplete) {
+			seen[v.Cluster] = true
+			names = append(names, v.Cluster)
+		}
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// Options completes the last key of the comma separated engine options
+// with the options of the engine, provider and version set by the command flags
+func Options(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, toComplete = toComplete[:i+1], toComplete[i+1:]
+	}
+	if strings.Contains(toComplete, "=") {
+		return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+
+	list, err := dbaas.ListOptions(instance(cmd))
+	if err != nil {
+		cobra.CompErrorln("list options: " + err.Error())
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var keys []string
+	for _, o := range client.FilterOptions(list, "spec.", toComplete) {
+		keys = append(keys, prefix+o.Path+"=")
+	}
+
+	return keys, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// Plans completes sizing plan names
+func Plans(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return config.Current().PlanNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// ExposeTypes completes the cluster expose types
+func ExposeTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return dbaas.ExposeTypes(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func instance(cmd *cobra.Command) dbaas.Instance {
+	engine, _ := cmd.Flags().GetString("engine")
+	provider, _ := cmd.Flags().GetString("provider")
+	i := client.GetInstance("", "", engine, provider, "")
+	if cmd.Flags().Lookup("version") != nil {
+		i.Version, _ = cmd.Flags().GetString("version")
+	}
+
+	return i
+}
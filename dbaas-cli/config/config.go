@@ -0,0 +1,210 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/k8s"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
+)
+
+const (
+	fileName  = "config.yaml"
+	envPrefix = "PERCONA_DBAAS_"
+)
+
+// Config holds CLI defaults. Every value can be overridden by PERCONA_DBAAS_<KEY> environment variable,
+// where the key path is upper cased and separated by "_", e.g. PERCONA_DBAAS_BACKUP_STORAGE_BUCKET
+type Config struct {
+	Provider      string         `json:"provider,omitempty"`
+	Namespace     string         `json:"namespace,omitempty"`
+	Env           string         `json:"env,omitempty"`
+	KubeContext   string         `json:"kubeContext,omitempty"`
+	Version       string         `json:"version,omitempty"`
+	Output        string         `json:"output,omitempty"`
+	StorageClass  string         `json:"storageClass,omitempty"`
+	Resources     *Resources     `json:"resources,omitempty"`
+	BackupStorage *BackupStorage `json:"backupStorage,omitempty"`
+	// Plan is the sizing plan of the created clusters
+	Plan  string          `json:"plan,omitempty"`
+	Plans map[string]Plan `json:"plans,omitempty"`
+}
+
+// Resources are CPU and memory requests of the database nodes
+type Resources struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
+
+// BackupStorage is the S3 storage of the created clusters backups
+type BackupStorage struct {
+	Name              string `json:"name,omitempty"`
+	Bucket            string `json:"bucket,omitempty"`
+	Region            string `json:"region,omitempty"`
+	EndpointURL       string `json:"endpointUrl,omitempty"`
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// StorageSpec returns the storage name and its spec in the operator CR
+func (b BackupStorage) StorageSpec() (string, k8s.BackupStorageSpec) {
+	name := b.Name
+	if len(name) == 0 {
+		name = k8s.DefaultBcpStorageName
+	}
+
+	return name, k8s.BackupStorageSpec{
+		Type: k8s.BackupStorageS3,
+		S3: k8s.BackupStorageS3Spec{
+			Bucket:            b.Bucket,
+			Region:            b.Region,
+			EndpointURL:       b.EndpointURL,
+			CredentialsSecret: b.CredentialsSecret,
+		},
+	}
+}
+
+// BackupStorageOption returns the engine option which adds the backup storage of the config to the cluster
+func (c Config) BackupStorageOption() (string, error) {
+	if c.BackupStorage == nil {
+		return "", nil
+	}
+	name, storage := c.BackupStorage.StorageSpec()
+	data, err := json.Marshal(storage)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal backup storage")
+	}
+
+	return "spec.backup.storages[" + name + "]=" + string(data), nil
+}
+
+var current Config
+
+// Current returns the config loaded by Load
+func Current() Config {
+	return current
+}
+
+// Path returns the config file path
+func Path() string {
+	return filepath.Join(k8s.EnvsDir(), fileName)
+}
+
+// Load reads the config file and applies environment variables overrides to it.
+// The result is available with Current
+func Load() (Config, error) {
+	c, err := LoadFile()
+	if err != nil {
+		return c, err
+	}
+	err = c.applyEnv()
+	if err != nil {
+		return c, errors.Wrap(err, "apply environment variables")
+	}
+	current = c
+
+	return c, nil
+}
+
+// LoadFile reads the config file without environment variables overrides.
+// Empty config is returned if there is no file
+func LoadFile() (Config, error) {
+	var c Config
+	data, err := ioutil.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return c, errors.Wrap(err, "read config file")
+	}
+	err = yaml.Unmarshal(data, &c)
+
+	return c, errors.Wrapf(err, "parse %s", Path())
+}
+
+// Save writes the config to the config file
+func (c Config) Save() error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return errors.Wrap(err, "marshal config")
+	}
+	err = os.MkdirAll(filepath.Dir(Path()), 0700)
+	if err != nil {
+		return errors.Wrap(err, "create config dir")
+	}
+
+	return errors.Wrap(ioutil.WriteFile(Path(), data, 0600), "write config file")
+}
+
+// Set sets the value of the given key. YAML or JSON values of maps and structs are given in braces
+func (c *Config) Set(key, value string) error {
+	if !strings.HasPrefix(value, "{") {
+		value = options.Quote(value)
+	}
+
+	return options.Parse(c, reflect.TypeOf(*c), key+"="+value)
+}
+
+// Get returns the value of the given key
+func (c Config) Get(key string) (string, error) {
+	for _, o := range options.List(&c) {
+		if strings.EqualFold(o.Path, key) {
+			return o.Default, nil
+		}
+	}
+
+	return "", errors.Errorf("unknown key %s", key)
+}
+
+// Keys returns all config keys
+func Keys() []string {
+	var keys []string
+	for _, o := range options.List(&Config{}) {
+		keys = append(keys, o.Path)
+	}
+
+	return keys
+}
+
+func (c *Config) applyEnv() error {
+	for _, key := range Keys() {
+		value, ok := os.LookupEnv(EnvName(key))
+		if !ok {
+			continue
+		}
+		err := c.Set(key, value)
+		if err != nil {
+			return errors.Wrap(err, EnvName(key))
+		}
+	}
+
+	return nil
+}
+
+// EnvName returns the environment variable name for the given key
+func EnvName(key string) string {
+	var b strings.Builder
+	b.WriteString(envPrefix)
+	prev := '.'
+	for _, r := range key {
+		switch {
+		case r == '.':
+			b.WriteRune('_')
+		case unicode.IsUpper(r) && prev != '.' && !unicode.IsUpper(prev):
+			b.WriteRune('_')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(unicode.ToUpper(r))
+		}
+		prev = r
+	}
+
+	return b.String()
+}
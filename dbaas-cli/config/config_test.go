@@ -0,0 +1,28 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/config"
+)
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"provider", "PERCONA_DBAAS_PROVIDER"},
+		{"kubeContext", "PERCONA_DBAAS_KUBE_CONTEXT"},
+		{"storageClass", "PERCONA_DBAAS_STORAGE_CLASS"},
+		{"resources.cpu", "PERCONA_DBAAS_RESOURCES_CPU"},
+		{"backupStorage.endpointUrl", "PERCONA_DBAAS_BACKUP_STORAGE_ENDPOINT_URL"},
+		{"backupStorage.credentialsSecret", "PERCONA_DBAAS_BACKUP_STORAGE_CREDENTIALS_SECRET"},
+	}
+
+	for _, tt := range tests {
+		got := config.EnvName(tt.key)
+		if got != tt.want {
+			t.Errorf("got %s, want %s", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// CustomPlan leaves the cluster sizing to the engine defaults and options
+const CustomPlan = "custom"
+
+// Plan is a cluster sizing preset
+type Plan struct {
+	Nodes        int32      `json:"nodes,omitempty"`
+	Requests     *Resources `json:"requests,omitempty"`
+	Limits       *Resources `json:"limits,omitempty"`
+	StorageSize  string     `json:"storageSize,omitempty"`
+	StorageClass string     `json:"storageClass,omitempty"`
+}
+
+// builtinPlans are used unless the config has plans with the same names
+var builtinPlans = map[string]Plan{
+	"small": {
+		Nodes:       3,
+		Requests:    &Resources{CPU: "600m", Memory: "1G"},
+		Limits:      &Resources{CPU: "1", Memory: "2G"},
+		StorageSize: "6G",
+	},
+	"medium": {
+		Nodes:       3,
+		Requests:    &Resources{CPU: "2", Memory: "4G"},
+		Limits:      &Resources{CPU: "2", Memory: "4G"},
+		StorageSize: "50G",
+	},
+	"large": {
+		Nodes:       5,
+		Requests:    &Resources{CPU: "4", Memory: "16G"},
+		Limits:      &Resources{CPU: "4", Memory: "16G"},
+		StorageSize: "200G",
+	},
+}
+
+// GetPlan returns the plan with the given name. Empty plan is returned for the custom plan
+func (c Config) GetPlan(name string) (Plan, error) {
+	if name == CustomPlan {
+		return Plan{}, nil
+	}
+	if p, ok := c.Plans[name]; ok {
+		return p, nil
+	}
+	if p, ok := builtinPlans[name]; ok {
+		return p, nil
+	}
+
+	return Plan{}, errors.Errorf("unknown plan '%s', use one of: %v", name, c.PlanNames())
+}
+
+// ClusterDefaults returns the cluster settings of the plan with the given name. The storage class and resources
+// which the plan doesn't set are taken from the config for new clusters. Storage is set only for new clusters
+// since volumes of existing clusters aren't changed with the spec
+func (c Config) ClusterDefaults(plan string, newCluster bool) (dbaas.ClusterSettings, error) {
+	var s dbaas.ClusterSettings
+	if newCluster {
+		s.StorageClass = c.StorageClass
+		if c.Resources != nil {
+			s.CPU = c.Resources.CPU
+			s.Memory = c.Resources.Memory
+		}
+	}
+	if len(plan) == 0 {
+		return s, nil
+	}
+	p, err := c.GetPlan(plan)
+	if err != nil {
+		return s, err
+	}
+
+	s.Size = int(p.Nodes)
+	if p.Requests != nil {
+		s.CPU = orDefault(p.Requests.CPU, s.CPU)
+		s.Memory = orDefault(p.Requests.Memory, s.Memory)
+	}
+	if p.Limits != nil {
+		s.CPULimit = p.Limits.CPU
+		s.MemoryLimit = p.Limits.Memory
+	}
+	if newCluster {
+		s.StorageSize = p.StorageSize
+		s.StorageClass = orDefault(p.StorageClass, s.StorageClass)
+	}
+
+	return s, nil
+}
+
+func orDefault(value, def string) string {
+	if len(value) == 0 {
+		return def
+	}
+
+	return value
+}
+
+// PlanNames returns names of the built-in and configured plans
+func (c Config) PlanNames() []string {
+	names := []string{CustomPlan}
+	for name := range builtinPlans {
+		names = append(names, name)
+	}
+	for name := range c.Plans {
+		if _, ok := builtinPlans[name]; !ok && name != CustomPlan {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
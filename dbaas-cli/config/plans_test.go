@@ -0,0 +1,47 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-cli/config"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+func TestClusterDefaults(t *testing.T) {
+	c := config.Config{
+		StorageClass: "standard",
+		Resources:    &config.Resources{CPU: "500m", Memory: "1G"},
+		Plans: map[string]config.Plan{
+			"tiny": {Nodes: 1, Requests: &config.Resources{Memory: "512M"}, StorageSize: "1G"},
+		},
+	}
+
+	tests := []struct {
+		plan       string
+		newCluster bool
+		want       dbaas.ClusterSettings
+	}{
+		{"", true, dbaas.ClusterSettings{StorageClass: "standard", CPU: "500m", Memory: "1G"}},
+		{"", false, dbaas.ClusterSettings{}},
+		{"tiny", true, dbaas.ClusterSettings{Size: 1, StorageSize: "1G", StorageClass: "standard", CPU: "500m", Memory: "512M"}},
+		{"tiny", false, dbaas.ClusterSettings{Size: 1, Memory: "512M"}},
+		{"small", false, dbaas.ClusterSettings{Size: 3, CPU: "600m", Memory: "1G", CPULimit: "1", MemoryLimit: "2G"}},
+	}
+
+	for _, tt := range tests {
+		got, err := c.ClusterDefaults(tt.plan, tt.newCluster)
+		if err != nil {
+			t.Errorf("plan %q: %v", tt.plan, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("got %+v, want %+v", got, tt.want)
+		}
+	}
+
+	_, err := c.ClusterDefaults("huge", true)
+	if err == nil {
+		t.Error("expected error for unknown plan")
+	}
+}
@@ -0,0 +1,19 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// PrintFindings prints diagnostics findings as a numbered list
+func PrintFindings(out io.Writer, findings []dbaas.Finding) {
+	if len(findings) == 0 {
+		fmt.Fprintln(out, "No problems found")
+		return
+	}
+	for i, f := range findings {
+		fmt.Fprintf(out, "%d. %s\n", i+1, f)
+	}
+}
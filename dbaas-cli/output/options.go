@@ -0,0 +1,20 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
+)
+
+// PrintOptions prints engine options with their types and defaults as a table
+func PrintOptions(out io.Writer, list []options.Option) {
+	w := new(tabwriter.Writer)
+	w.Init(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "OPTION\tTYPE\tDEFAULT\t")
+	for _, o := range list {
+		fmt.Fprintf(w, "%s\t%s\t%s\t\n", o.Path, o.Type, valueOrDash(o.Default))
+	}
+	w.Flush()
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// PrintDBList prints the given databases as a table.
+// If withEngine is true engine and namespace columns are added
+func PrintDBList(out io.Writer, listDB []dbaas.DB, withEngine bool) {
+	w := new(tabwriter.Writer)
+	w.Init(out, 0, 8, 2, ' ', 0)
+	if withEngine {
+		fmt.Fprint(w, "ENGINE\tNAMESPACE\t")
+	}
+	fmt.Fprintln(w, "NAME\tSTATUS\tNODES\tPROXY\tSTORAGE\tSTORAGE CLASS\tCPU\tMEMORY\tPMM\tOPERATOR\tAGE\t")
+	for _, db := range listDB {
+		if withEngine {
+			fmt.Fprintf(w, "%s\t%s\t", db.Engine, valueOrDash(db.Namespace))
+		}
+		proxy := "none"
+		if len(db.Proxy) > 0 {
+			proxy = db.Proxy + " " + db.ProxyNodes.String()
+		}
+		pmm := "disabled"
+		if db.PMMEnabled {
+			pmm = "enabled"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
+			db.ResourceName, db.Status, db.Nodes, proxy, valueOrDash(db.Size), valueOrDash(db.StorageClass),
+			valueOrDash(db.CPU), valueOrDash(db.Memory), pmm, valueOrDash(db.OperatorVersion), Age(db.Created))
+	}
+	fmt.Fprintln(w)
+	w.Flush()
+}
+
+// Age returns the time passed since t in short human readable format like "5d" or "3h"
+func Age(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+func valueOrDash(s string) string {
+	if len(s) == 0 {
+		return "-"
+	}
+
+	return s
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// PrintVolumesResize prints resize state of the volumes as a table
+func PrintVolumesResize(out io.Writer, volumes []dbaas.VolumeResize) {
+	w := new(tabwriter.Writer)
+	w.Init(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "VOLUME\tCAPACITY\tREQUESTED\tSTATE\t")
+	for _, v := range volumes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", v.Name, valueOrDash(v.Capacity), valueOrDash(v.Requested), v.State)
+	}
+	w.Flush()
+}
+
+// PrintSteps prints the numbered steps
+func PrintSteps(out io.Writer, steps []string) {
+	for i, s := range steps {
+		fmt.Fprintf(out, "%d. %s\n", i+1, s)
+	}
+}
+
+// PrintVolumes prints the preserved volumes as a table
+func PrintVolumes(out io.Writer, volumes []dbaas.Volume) {
+	w := new(tabwriter.Writer)
+	w.Init(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "VOLUME\tCLUSTER\tENGINE\tCOMPONENT\tSIZE\tSTORAGE CLASS\tAGE\t")
+	for _, v := range volumes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", v.Name, v.Cluster, v.Engine, valueOrDash(v.Component),
+			valueOrDash(v.Size), valueOrDash(v.StorageClass), Age(v.Created))
+	}
+	w.Flush()
+}
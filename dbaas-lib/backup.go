@@ -0,0 +1,34 @@
+package dbaas
+
+import "github.com/pkg/errors"
+
+// Backup is the finished backup of the cluster
+type Backup struct {
+	Name        string `json:"name"`
+	Storage     string `json:"storage"`
+	Destination string `json:"destination"`
+	// Secret is the secret with the cluster users the backup is restored with.
+	// It is kept when the cluster is deleted while the cluster has backups
+	Secret string `json:"secret"`
+}
+
+// BackupEngine is implemented by engines which can take an on-demand backup of the cluster
+type BackupEngine interface {
+	// BackupDBCluster takes a backup of the cluster to the given storage and waits until it succeeds.
+	// The storage may be empty if the cluster has only one backup storage
+	BackupDBCluster(name, storage string) (Backup, error)
+}
+
+// BackupDB takes a backup of the instance DB resource to the given storage and waits until it succeeds
+func BackupDB(instance Instance, storage string) (Backup, error) {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return Backup{}, err
+	}
+	backupEng, ok := eng.(BackupEngine)
+	if !ok {
+		return Backup{}, errors.Errorf("engine %s doesn't support backups", instance.Engine)
+	}
+
+	return backupEng.BackupDBCluster(instance.Name, storage)
+}
@@ -0,0 +1,57 @@
+package dbaas
+
+import "github.com/pkg/errors"
+
+// CloneOptions are the options of the cluster clone
+type CloneOptions struct {
+	// LatestBackup is true if the latest succeeded backup of the source is used instead of a fresh one
+	LatestBackup bool
+	// Storage is the backup storage of the fresh backup. It may be empty if the source has only one storage
+	Storage string
+}
+
+// CloneEngine is implemented by engines which can clone the cluster into a new one
+type CloneEngine interface {
+	// CloneDBCluster takes a backup of the source cluster or finds the latest one, creates the target
+	// cluster with the source spec and users and returns the backup name to restore into the target
+	CloneDBCluster(source, target string, opts CloneOptions) (string, error)
+	// RestoreDBCluster restores the backup into the cluster and waits until the restore is finished
+	RestoreDBCluster(name, backupName string) error
+}
+
+// CloneDB creates a new DB resource with the given name and the spec of the instance DB resource.
+// It returns the backup of the instance to restore into the new DB resource with RestoreDB when it is ready
+func CloneDB(instance Instance, target string, opts CloneOptions) (string, error) {
+	if instance.Name == target {
+		return "", errors.New("source and target names should be different")
+	}
+	eng, err := cloneEngine(instance)
+	if err != nil {
+		return "", err
+	}
+
+	return eng.CloneDBCluster(instance.Name, target, opts)
+}
+
+// RestoreDB restores the backup into the instance DB resource
+func RestoreDB(instance Instance, backupName string) error {
+	eng, err := cloneEngine(instance)
+	if err != nil {
+		return err
+	}
+
+	return eng.RestoreDBCluster(instance.Name, backupName)
+}
+
+func cloneEngine(instance Instance) (CloneEngine, error) {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return nil, err
+	}
+	clone, ok := eng.(CloneEngine)
+	if !ok {
+		return nil, errors.Errorf("engine %s doesn't support cloning", instance.Engine)
+	}
+
+	return clone, nil
+}
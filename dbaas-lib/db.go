@@ -1,6 +1,11 @@
 package dbaas
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
 
 type State string
 
@@ -11,17 +16,72 @@ const (
 	StateError   State = "error"
 )
 
+// Nodes represents number of ready and desired members of a cluster component
+type Nodes struct {
+	Ready   int32 `json:"ready"`
+	Desired int32 `json:"desired"`
+}
+
+func (n Nodes) String() string {
+	return fmt.Sprintf("%d/%d", n.Ready, n.Desired)
+}
+
+// Replset represents state and settings of a replica set of the cluster
+type Replset struct {
+	Name     string `json:"name"`
+	Status   State  `json:"status,omitempty"`
+	Nodes    Nodes  `json:"nodes"`
+	Arbiters int32  `json:"arbiters,omitempty"`
+	Size     string `json:"size,omitempty"`
+	CPU      string `json:"cpu,omitempty"`
+	Memory   string `json:"memory,omitempty"`
+}
+
+func (r Replset) String() string {
+	s := fmt.Sprintf("%s: %s %s", r.Name, valueOrNone(string(r.Status)), r.Nodes)
+	switch {
+	case r.Arbiters == 1:
+		s += " +1 arbiter"
+	case r.Arbiters > 1:
+		s += fmt.Sprintf(" +%d arbiters", r.Arbiters)
+	}
+	if len(r.Size) > 0 {
+		s += ", storage " + r.Size
+	}
+	if len(r.CPU) > 0 || len(r.Memory) > 0 {
+		s += fmt.Sprintf(", cpu=%s, memory=%s", valueOrNone(r.CPU), valueOrNone(r.Memory))
+	}
+
+	return s
+}
+
 type DB struct {
-	ResourceName     string `json:"resourceName,omitempty"`
-	ResourceEndpoint string `json:"resourceEndpoint,omitempty"`
-	Size             string `json:"size,omitempty"`
-	Port             int    `json:"port,omitempty"`
-	User             string `json:"user,omitempty"`
-	Pass             string `json:"pass,omitempty"`
-	Status           State  `json:"status,omitempty"`
-	Engine           string `json:"engine,omitempty"`
-	Provider         string `json:"provider,omitempty"`
-	Message          string `json:"message,omitempty"`
+	ResourceName     string            `json:"resourceName,omitempty"`
+	Namespace        string            `json:"namespace,omitempty"`
+	ResourceEndpoint string            `json:"resourceEndpoint,omitempty"`
+	Size             string            `json:"size,omitempty"`
+	StorageClass     string            `json:"storageClass,omitempty"`
+	Port             int               `json:"port,omitempty"`
+	User             string            `json:"user,omitempty"`
+	Pass             string            `json:"pass,omitempty"`
+	Status           State             `json:"status,omitempty"`
+	Engine           string            `json:"engine,omitempty"`
+	Provider         string            `json:"provider,omitempty"`
+	Nodes            Nodes             `json:"nodes"`
+	Proxy            string            `json:"proxy,omitempty"`
+	ProxyNodes       Nodes             `json:"proxyNodes"`
+	Replsets         []Replset         `json:"replsets,omitempty"`
+	Images           map[string]string `json:"images,omitempty"`
+	CPU              string            `json:"cpu,omitempty"`
+	Memory           string            `json:"memory,omitempty"`
+	PMMEnabled       bool              `json:"pmmEnabled"`
+	PMMServer        string            `json:"pmmServer,omitempty"`
+	TLS              bool              `json:"tls"`
+	OperatorVersion  string            `json:"operatorVersion,omitempty"`
+	Created          time.Time         `json:"created"`
+	Expires          *time.Time        `json:"expires,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	Message          string            `json:"message,omitempty"`
 }
 
 func (d DB) String() string {
@@ -57,10 +117,107 @@ func (d DB) String() string {
 	if len(d.Status) > 0 {
 		status = fmt.Sprintf("\nStatus:            %s", d.Status)
 	}
+	nodes := ""
+	if d.Nodes.Desired > 0 {
+		nodes = fmt.Sprintf("\nNodes:             %s", d.Nodes)
+	}
+	proxy := ""
+	if len(d.Proxy) > 0 {
+		proxy = fmt.Sprintf("\nProxy:             %s %s", d.Proxy, d.ProxyNodes)
+	}
+	replsets := ""
+	if len(d.Replsets) > 0 {
+		lines := make([]string, 0, len(d.Replsets))
+		for _, rs := range d.Replsets {
+			lines = append(lines, rs.String())
+		}
+		replsets = "\nReplsets:          " + strings.Join(lines, "\n                   ")
+	}
+	storage := ""
+	if len(d.Size) > 0 {
+		storage = fmt.Sprintf("\nStorage:           %s", d.Size)
+		if len(d.StorageClass) > 0 {
+			storage += fmt.Sprintf(" (%s)", d.StorageClass)
+		}
+	}
+	resources := ""
+	if len(d.CPU) > 0 || len(d.Memory) > 0 {
+		resources = fmt.Sprintf("\nRequests:          cpu=%s, memory=%s", valueOrNone(d.CPU), valueOrNone(d.Memory))
+	}
+	images := ""
+	if len(d.Images) > 0 {
+		images = "\nImages:            " + imagesString(d.Images, "\n                   ")
+	}
+	pmm := ""
+	if len(d.Engine) > 0 {
+		pmm = "\nPMM:               disabled"
+		if d.PMMEnabled {
+			pmm = "\nPMM:               enabled"
+			if len(d.PMMServer) > 0 {
+				pmm += " (server " + d.PMMServer + ")"
+			}
+		}
+	}
+	tls := ""
+	if len(d.Engine) > 0 {
+		tls = "\nTLS:               disabled"
+		if d.TLS {
+			tls = "\nTLS:               enabled"
+		}
+	}
+	operator := ""
+	if len(d.OperatorVersion) > 0 {
+		operator = fmt.Sprintf("\nOperator Version:  %s", d.OperatorVersion)
+	}
+	created := ""
+	if !d.Created.IsZero() {
+		created = fmt.Sprintf("\nCreated:           %s", d.Created.Format(time.RFC3339))
+	}
+	expires := ""
+	if d.Expires != nil {
+		expires = fmt.Sprintf("\nExpires:           %s (%s)", d.Expires.Format(time.RFC3339), d.ExpiresIn())
+	}
 	message := ""
 	if len(d.Message) > 0 {
 		message = fmt.Sprintf("\n\n%s\n", d.Message)
 	}
 
-	return provider + engine + resourceName + resourceEndpoint + port + user + pass + status + message
+	return provider + engine + resourceName + resourceEndpoint + port + user + pass + status +
+		nodes + proxy + replsets + storage + resources + images + pmm + tls + operator + created + expires + message
+}
+
+// ExpiresIn returns the remaining time until the DB resource expires in human readable format
+func (d DB) ExpiresIn() string {
+	if d.Expires == nil {
+		return "never"
+	}
+	left := time.Until(*d.Expires)
+	if left <= 0 {
+		return "expired"
+	}
+
+	return "in " + left.Truncate(time.Minute).String()
+}
+
+func valueOrNone(s string) string {
+	if len(s) == 0 {
+		return "none"
+	}
+
+	return s
+}
+
+func imagesString(images map[string]string, sep string) string {
+	components := make([]string, 0, len(images))
+	for c := range images {
+		components = append(components, c)
+	}
+	sort.Strings(components)
+
+	lines := make([]string, 0, len(components))
+	for _, c := range components {
+		lines = append(lines, c+": "+images[c])
+	}
+
+	return strings.Join(lines, sep)
 }
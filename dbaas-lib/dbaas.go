@@ -1,7 +1,14 @@
 package dbaas
 
 import (
+	"io"
+	"sort"
+	"sync"
+	"time"
+
 	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
 )
 
 type Instance struct {
@@ -10,66 +17,182 @@ type Instance struct {
 	Provider      string
 	ClusterSize   int
 	DiskSize      string
+	StorageClass  string
+	CPU           string
+	Memory        string
+	Expose        string
 	EngineOptions string
 	RootPass      string
 	Version       string
+	Env           Environment
+
+	// DeletionProtection enables or disables the deletion protection, it isn't changed if it is nil
+	DeletionProtection *bool
+	// Labels are added to the DB resource and its secrets
+	Labels map[string]string
+	// Annotations are added to the DB resource and its secrets
+	Annotations map[string]string
+	// TTL is the time from now after which the DB resource expires, it doesn't expire if it is 0
+	TTL time.Duration
+	// PMMServer enables monitoring of the DB resource with the PMM server on the given host
+	PMMServer string
+	// PMMUser and PMMPassword are the PMM server credentials stored in the DB resource secrets
+	PMMUser     string
+	PMMPassword string
+	// TLS and Encryption configure the security of the new DB resource
+	TLS        TLS
+	Encryption Encryption
+	// Defaults are the settings applied before the engine options, e.g. the sizing plan.
+	// The engine options and the settings above take precedence over them
+	Defaults ClusterSettings
 }
 
 // CreateDB creates DB resource using name, provider, engine and options given in 'instance' object. The default value provider=k8s, engine=pxc
 func CreateDB(instance Instance) error {
-	err := checkProviderAndEngine(instance)
+	eng, err := getEngine(instance)
 	if err != nil {
 		return err
 	}
 
-	err = Providers[instance.Provider].Engines[instance.Engine].CreateDBCluster(instance.Name, instance.EngineOptions, instance.RootPass, instance.Version)
+	opts, err := engineOptions(eng, instance)
 	if err != nil {
 		return err
 	}
 
+	err = setupSecurity(eng, instance, opts)
+	if err != nil {
+		return err
+	}
+	// the credentials are stored before the creation so the PMM client starts with them
+	err = setPMMCredentials(eng, instance)
+	if err != nil {
+		return cleanupSecurity(eng, instance, err)
+	}
+	err = eng.CreateDBCluster(instance.Name, opts, instance.RootPass, instance.Version)
+	if err != nil {
+		return cleanupSecurity(eng, instance, err)
+	}
+	err = setSecretsMetadata(eng, instance)
+	if err != nil {
+		return err
+	}
+	if instance.DeletionProtection != nil && *instance.DeletionProtection {
+		err = setDeletionProtection(eng, instance.Name, true)
+		if err != nil {
+			return errors.Wrap(err, "set deletion protection")
+		}
+	}
+
 	return nil
 }
 
 // ModifyDB modifies DB resource using name, provider, engine and options given in 'instance' object. The default value provider=k8s, engine=pxc
 func ModifyDB(instance Instance) error {
-	err := checkProviderAndEngine(instance)
+	eng, err := getEngine(instance)
 	if err != nil {
 		return err
 	}
 
-	err = Providers[instance.Provider].Engines[instance.Engine].UpdateDBCluster(instance.Name, instance.EngineOptions, instance.Version)
+	opts, err := engineOptions(eng, instance)
 	if err != nil {
 		return err
 	}
 
+	err = setPMMCredentials(eng, instance)
+	if err != nil {
+		return err
+	}
+	err = eng.UpdateDBCluster(instance.Name, opts, instance.Version)
+	if err != nil {
+		return err
+	}
+	err = setSecretsMetadata(eng, instance)
+	if err != nil {
+		return err
+	}
+	if instance.DeletionProtection != nil {
+		err = setDeletionProtection(eng, instance.Name, *instance.DeletionProtection)
+		if err != nil {
+			return errors.Wrap(err, "set deletion protection")
+		}
+	}
+
 	return nil
 }
 
 func DescribeDB(instance Instance) (DB, error) {
-	err := checkProviderAndEngine(instance)
+	eng, err := getEngine(instance)
 	if err != nil {
 		return DB{}, err
 	}
 
-	return Providers[instance.Provider].Engines[instance.Engine].GetDBCluster(instance.Name, instance.EngineOptions)
+	return eng.GetDBCluster(instance.Name, instance.EngineOptions)
 }
 
 func ListDB(instance Instance) ([]DB, error) {
-	err := checkProviderAndEngine(instance)
+	eng, err := getEngine(instance)
 	if err != nil {
 		return nil, err
 	}
 
-	return Providers[instance.Provider].Engines[instance.Engine].GetDBClusterList()
+	return eng.GetDBClusterList(false)
+}
+
+// ListAllDB returns databases of every registered provider and engine in the given environment.
+// If allNamespaces is false only the current namespace is listed
+func ListAllDB(env Environment, allNamespaces bool) ([]DB, error) {
+	var list []DB
+	for _, providerName := range sortedKeys(Providers) {
+		for _, engineName := range engineNames(providerName) {
+			eng, err := setupEngine(providerName, engineName, env)
+			if err != nil {
+				return nil, err
+			}
+			dbs, err := eng.GetDBClusterList(allNamespaces)
+			if err != nil {
+				return nil, errors.Wrapf(err, "list %s/%s", providerName, engineName)
+			}
+			list = append(list, dbs...)
+		}
+	}
+
+	return list, nil
+}
+
+func sortedKeys(providers map[string]Provider) []string {
+	keys := make([]string, 0, len(providers))
+	for k := range providers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// engineNames returns sorted names of the engines registered for the provider
+func engineNames(providerName string) []string {
+	factories := Providers[providerName].Engines
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
 }
 
 func DeleteDB(instance Instance, saveData bool) (string, error) {
-	err := checkProviderAndEngine(instance)
+	eng, err := getEngine(instance)
+	if err != nil {
+		return "", err
+	}
+
+	err = checkDeletionProtection(eng, instance.Name)
 	if err != nil {
 		return "", err
 	}
 
-	return Providers[instance.Provider].Engines[instance.Engine].DeleteDBCluster(instance.Name, instance.EngineOptions, instance.Version, saveData)
+	return eng.DeleteDBCluster(instance.Name, instance.EngineOptions, instance.Version, saveData)
 }
 
 func checkProviderAndEngine(instance Instance) error {
@@ -83,11 +206,76 @@ func checkProviderAndEngine(instance Instance) error {
 	return nil
 }
 
-func PreCheck(instance Instance) ([]string, error) {
+// engineKey identifies the engine set up for the environment
+type engineKey struct {
+	provider string
+	engine   string
+	env      Environment
+}
+
+var (
+	engines   = make(map[engineKey]Engine)
+	enginesMu sync.Mutex
+)
+
+// getEngine returns the instance engine set up for the instance environment.
+// The engine is created on the first use and reused after that
+func getEngine(instance Instance) (Engine, error) {
 	err := checkProviderAndEngine(instance)
 	if err != nil {
 		return nil, err
 	}
 
-	return Providers[instance.Provider].Engines[instance.Engine].PreCheck(instance.Name, instance.EngineOptions, instance.Version)
+	return setupEngine(instance.Provider, instance.Engine, instance.Env)
+}
+
+func setupEngine(providerName, engineName string, env Environment) (Engine, error) {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+
+	key := engineKey{provider: providerName, engine: engineName, env: env}
+	if eng, ok := engines[key]; ok {
+		return eng, nil
+	}
+	eng, err := Providers[providerName].Engines[engineName](env)
+	if err != nil {
+		return nil, errors.Wrapf(err, "setup %s engine", engineName)
+	}
+	engines[key] = eng
+
+	return eng, nil
+}
+
+func PreCheck(instance Instance) ([]string, error) {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := engineOptions(eng, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return eng.PreCheck(instance.Name, opts, instance.Version)
+}
+
+// SupportBundle writes gzipped tar archive with the DB resource objects and logs to w
+func SupportBundle(instance Instance, w io.Writer) error {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return err
+	}
+
+	return eng.SupportBundle(instance.Name, w)
+}
+
+// ListOptions returns the options which can be set for the engine of the given version, the default version is used if it is empty
+func ListOptions(instance Instance) ([]options.Option, error) {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return eng.ListOptions(instance.Version)
 }
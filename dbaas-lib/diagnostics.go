@@ -0,0 +1,70 @@
+package dbaas
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Severity shows how urgent a diagnostics finding is
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
+)
+
+func (s Severity) priority() int {
+	switch s {
+	case SeverityCritical:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Finding is a problem found by cluster diagnostics
+type Finding struct {
+	Severity  Severity `json:"severity"`
+	Component string   `json:"component,omitempty"`
+	Problem   string   `json:"problem"`
+	Hint      string   `json:"hint,omitempty"`
+}
+
+func (f Finding) String() string {
+	component := ""
+	if len(f.Component) > 0 {
+		component = f.Component + ": "
+	}
+	hint := ""
+	if len(f.Hint) > 0 {
+		hint = "\n   Hint: " + f.Hint
+	}
+
+	return fmt.Sprintf("[%s] %s%s%s", f.Severity, component, f.Problem, hint)
+}
+
+// SortFindings orders findings from the most to the least severe keeping the order of equal ones
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity.priority() < findings[j].Severity.priority()
+	})
+}
+
+// Diagnose inspects DB resource with the given name and returns found problems ordered by severity
+func Diagnose(instance Instance) ([]Finding, error) {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := eng.Diagnose(instance.Name)
+	if err != nil {
+		return nil, err
+	}
+	SortFindings(findings)
+
+	return findings, nil
+}
@@ -1,29 +1,54 @@
 package dbaas
 
+import (
+	"io"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
+)
+
 type Engine interface {
 	ParseOptions(opts string) error
 	CreateDBCluster(name, opts, rootPass, version string) error
 	DeleteDBCluster(name, opts, version string, delePVC bool) (string, error)
 	GetDBCluster(name, opts string) (DB, error)
-	GetDBClusterList() ([]DB, error)
+	GetDBClusterList(allNamespaces bool) ([]DB, error)
 	UpdateDBCluster(name, opts, version string) error
 	PreCheck(name, opts, version string) ([]string, error)
+	Diagnose(name string) ([]Finding, error)
+	SupportBundle(name string, w io.Writer) error
+	StreamLogs(name string, opts LogOptions, w io.Writer) error
+	ListOptions(version string) ([]options.Option, error)
+	// ClusterOptions returns the engine options which apply the given cluster settings
+	ClusterOptions(s ClusterSettings) string
 }
 
+// Environment selects the Kubernetes cluster the engine works with
+type Environment struct {
+	// Name is the name of the environment saved in ~/.percona, the current one is used if it is empty
+	Name string
+	// KubeContext is the kubeconfig context, the current context is used if it is empty
+	KubeContext string
+	// Namespace is the Kubernetes namespace, the context namespace is used if it is empty
+	Namespace string
+}
+
+// EngineFactory returns the engine working with the given environment
+type EngineFactory func(env Environment) (Engine, error)
+
 var Providers = make(map[string]Provider)
 
 type Provider struct {
-	Engines map[string]Engine
+	Engines map[string]EngineFactory
 }
 
-func RegisterEngine(providerName, engineName string, eng Engine) {
+func RegisterEngine(providerName, engineName string, factory EngineFactory) {
 	if _, ok := Providers[providerName].Engines[engineName]; !ok && Providers[providerName].Engines == nil {
-		engns := map[string]Engine{
-			engineName: eng,
+		engns := map[string]EngineFactory{
+			engineName: factory,
 		}
 		Providers[providerName] = Provider{
 			Engines: engns,
 		}
 	}
-	Providers[providerName].Engines[engineName] = eng
+	Providers[providerName].Engines[engineName] = factory
 }
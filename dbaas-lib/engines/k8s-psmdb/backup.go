@@ -0,0 +1,26 @@
+package psmdb
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// BackupDBCluster takes a backup of the cluster to the given storage and waits until it succeeds
+func (p *PSMDB) BackupDBCluster(name, storage string) (dbaas.Backup, error) {
+	backup, err := p.cmd.TakeBackup(backupObjects, "psmdb", name, storage, backupTimeout)
+	if err != nil {
+		return dbaas.Backup{}, errors.Wrap(err, "take backup")
+	}
+	storage, destination, err := p.cmd.BackupLocation(backupObjects, backup)
+	if err != nil {
+		return dbaas.Backup{}, errors.Wrap(err, "get backup location")
+	}
+
+	return dbaas.Backup{
+		Name:        backup,
+		Storage:     storage,
+		Destination: destination,
+		Secret:      name + "-psmdb-users-secrets",
+	}, nil
+}
@@ -0,0 +1,114 @@
+package psmdb
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/k8s"
+)
+
+const (
+	backupTimeout  = 2 * time.Hour
+	restoreTimeout = 2 * time.Hour
+)
+
+var backupObjects = k8s.BackupObjects{
+	APIVersion:          "psmdb.percona.com/v1",
+	BackupKind:          "PerconaServerMongoDBBackup",
+	RestoreKind:         "PerconaServerMongoDBRestore",
+	BackupResource:      "perconaservermongodbbackup.psmdb.percona.com",
+	RestoreResource:     "perconaservermongodbrestore.psmdb.percona.com",
+	BackupClusterField:  "psmdbCluster",
+	RestoreClusterField: "clusterName",
+	BackupSucceeded:     "ready",
+	RestoreSucceeded:    "ready",
+	Failed:              []string{"error", "rejected"},
+}
+
+// CloneDBCluster takes a backup of the source cluster or finds the latest one and creates the target cluster
+// with the source spec and users. TLS secrets and backup schedule of the source aren't copied
+func (p *PSMDB) CloneDBCluster(source, target string, opts dbaas.CloneOptions) (string, error) {
+	err := p.setVersionObjectsWithDefaults(Version(""))
+	if err != nil {
+		return "", errors.Wrap(err, "version check")
+	}
+	ext, err := p.cmd.IsObjExists("psmdb", target)
+	if err != nil {
+		return "", errors.Wrap(err, "check if cluster exists")
+	}
+	if ext {
+		return "", k8s.ErrAlreadyExists{Typ: "psmdb", Cluster: target}
+	}
+	// the users secret of a deleted cluster is kept for its backups so it isn't overwritten
+	secretsName := target + "-psmdb-users-secrets"
+	ext, err = p.cmd.IsObjExists("secret", secretsName)
+	if err != nil {
+		return "", errors.Wrap(err, "check if secrets exist")
+	}
+	if ext {
+		return "", errors.Errorf("secret %s already exists", secretsName)
+	}
+
+	var backup string
+	if opts.LatestBackup {
+		backup, err = p.cmd.LatestBackup(backupObjects, source)
+	} else {
+		backup, err = p.cmd.TakeBackup(backupObjects, "psmdb", source, opts.Storage, backupTimeout)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "backup")
+	}
+
+	// the restored data has the users of the source so the target uses the same passwords
+	secrets, err := p.cmd.GetSecrets(source + "-psmdb-users-secrets")
+	if err != nil {
+		return "", errors.Wrap(err, "get source secrets")
+	}
+	err = p.cmd.CreateSecret(secretsName, secrets)
+	if err != nil {
+		return "", errors.Wrap(err, "create secrets")
+	}
+
+	cr, err := p.cmd.CloneCR("psmdb", source, target, func(spec map[string]interface{}) {
+		// TLS secrets are generated by the operator for the target cluster
+		spec["secrets"] = map[string]interface{}{"users": secretsName}
+		if backup, ok := spec["backup"].(map[string]interface{}); ok {
+			delete(backup, "schedule")
+		}
+	})
+	if err != nil {
+		return "", p.deleteCloneSecrets(secretsName, errors.Wrap(err, "clone cr"))
+	}
+	err = p.cmd.CreateCluster("psmdb", p.conf.GetOperatorImage(), target, cr, p.bundle)
+	if err != nil {
+		return "", p.deleteCloneSecrets(secretsName, errors.Wrap(err, "create cluster"))
+	}
+
+	return backup, nil
+}
+
+// deleteCloneSecrets deletes the users secret of the target cluster which cloning failed with the given error
+func (p *PSMDB) deleteCloneSecrets(name string, err error) error {
+	derr := p.cmd.DeleteObject("secret", name)
+	if derr != nil {
+		return errors.Errorf("%v; delete secrets: %v", err, derr)
+	}
+
+	return err
+}
+
+// RestoreDBCluster restores the backup into the cluster and waits until the restore is finished
+func (p *PSMDB) RestoreDBCluster(name, backupName string) error {
+	restore, err := p.cmd.CreateRestore(backupObjects, name, backupName)
+	if err != nil {
+		return errors.Wrap(err, "create restore")
+	}
+	err = p.cmd.WaitState(backupObjects.RestoreResource, restore, backupObjects.RestoreSucceeded, backupObjects.Failed, restoreTimeout)
+	if err != nil {
+		return errors.Wrap(err, "wait restore")
+	}
+
+	return nil
+}
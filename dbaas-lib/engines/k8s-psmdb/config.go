@@ -15,4 +15,5 @@ type PSMDBCluster interface {
 	SetupMiniConfig()
 	GetStatus() dbaas.State
 	GetReplestsNames() []string
+	GetDBInfo() dbaas.DB
 }
@@ -3,6 +3,7 @@ package psmdb
 import (
 	"crypto/rand"
 	"encoding/json"
+	"io"
 	"math/big"
 	mrand "math/rand"
 	"strings"
@@ -27,7 +28,7 @@ func (p *PSMDB) CreateDBCluster(name, opts, rootPass, version string) error {
 	p.conf.SetName(name)
 	p.conf.SetUsersSecretName(name)
 
-	switch p.platformType {
+	switch p.cmd.GetPlatformType() {
 	case k8s.PlatformMinishift, k8s.PlatformMinikube:
 		p.conf.SetupMiniConfig()
 	}
@@ -89,8 +90,8 @@ func (p *PSMDB) DeleteDBCluster(name, opts, version string, delePVC bool) (strin
 		}
 
 		rsName := "rs0"
-		for _, name := range st.GetReplestsNames() {
-			rsName = name
+		if names := st.GetReplestsNames(); len(names) > 0 {
+			rsName = names[0]
 		}
 
 		pvcObj, err := p.cmd.GetObject("pvc", "mongod-data-"+name+"-"+rsName+"-0")
@@ -104,6 +105,14 @@ func (p *PSMDB) DeleteDBCluster(name, opts, version string, delePVC bool) (strin
 		}
 		return "pvc/" + pvc.Name, nil
 	}
+	// backups of the cluster can't be restored without the users secret
+	hasBackups, err := p.cmd.HasBackups(backupObjects, name)
+	if err != nil {
+		return "", errors.Wrap(err, "check backups")
+	}
+	if hasBackups {
+		return "", nil
+	}
 	err = p.cmd.DeleteObject("secret", name+"-psmdb-users-secrets")
 	if err != nil {
 		return "", errors.Wrap(err, "delete secret")
@@ -140,9 +149,10 @@ func (p *PSMDB) GetDBCluster(name, opts string) (dbaas.DB, error) {
 		return db, err
 	}
 	rsName := "rs0"
-	for _, name := range st.GetReplestsNames() {
-		rsName = name
+	if names := st.GetReplestsNames(); len(names) > 0 {
+		rsName = names[0]
 	}
+	svcName := name + "-" + rsName
 	ns, err := p.cmd.GetCurrentNamespace()
 	if err != nil {
 		return db, errors.Wrap(err, "get namspace name")
@@ -150,25 +160,48 @@ func (p *PSMDB) GetDBCluster(name, opts string) (dbaas.DB, error) {
 	if len(ns) == 0 {
 		ns = "default"
 	}
+	db = st.GetDBInfo()
 	db.Provider = provider
 	db.Engine = engine
+	db.Expires = k8s.ExpiresAt(cluster)
+	db.PMMServer = k8s.PMMServer(cluster)
+	tlsSecret := k8s.TLSSecret(cluster, name, "secrets", "ssl")
+	db.TLS, err = p.cmd.IsObjExists("secret", tlsSecret)
+	if err != nil {
+		return db, errors.Wrap(err, "check TLS secret")
+	}
+	db.OperatorVersion = p.deployedOperatorVersion()
 	db.ResourceName = name
-	db.ResourceEndpoint = name + "-" + rsName + "." + ns + ".psmdb.svc.local"
+	db.ResourceEndpoint = svcName + "." + ns + ".psmdb.svc.local"
 	db.Port = 27017
 	db.User = string(secrets["MONGODB_CLUSTER_ADMIN_USER"])
 	db.Pass = string(secrets["MONGODB_CLUSTER_ADMIN_PASSWORD"])
 	db.Status = st.GetStatus()
 	if st.GetStatus() == dbaas.StateReady {
-		db.Message = "To access database please run the following commands:\nkubectl port-forward svc/" + name + "-" + rsName + " 27017:27017 &\nmongo mongodb://" + db.User + ":PASSWORD@localhost:27017/admin?ssl=false"
+		db.Message = "To access database please run the following commands:\nkubectl port-forward svc/" + svcName + " 27017:27017 &\nmongo mongodb://" + db.User + ":PASSWORD@localhost:27017/admin?ssl=false"
+		if db.TLS {
+			// the port-forwarded host doesn't match the certificate hosts so only the CA is verified
+			db.Message = "To access database please run the following commands:\nkubectl get secret " + tlsSecret + " -o jsonpath='{.data.ca\\.crt}' | base64 --decode > ca.crt\n" +
+				"kubectl port-forward svc/" + svcName + " 27017:27017 &\nmongo mongodb://" + db.User + ":PASSWORD@localhost:27017/admin?ssl=true --sslCAFile ca.crt --sslAllowInvalidHostnames"
+		}
 	}
 
 	return db, nil
 }
 
 // GetDBClusterList return list of existing DB obkects
-func (p *PSMDB) GetDBClusterList() ([]dbaas.DB, error) {
+func (p *PSMDB) GetDBClusterList(allNamespaces bool) ([]dbaas.DB, error) {
 	var dbList []dbaas.DB
-	cluster, err := p.cmd.GetObjects("psmdb")
+	var cluster []byte
+	var err error
+	if allNamespaces {
+		cluster, err = p.cmd.GetObjectsInAllNamespaces("psmdb")
+	} else {
+		cluster, err = p.cmd.GetObjects("psmdb")
+	}
+	if err == k8s.ErrNotFound {
+		return dbList, nil
+	}
 	if err != nil {
 		return dbList, errors.Wrap(err, "get cluster object")
 
@@ -183,21 +216,24 @@ func (p *PSMDB) GetDBClusterList() ([]dbaas.DB, error) {
 	if err != nil {
 		return dbList, errors.Wrap(err, "version check")
 	}
+	operatorVersion := p.deployedOperatorVersion()
 	for _, c := range st.Items {
 		b, err := json.Marshal(c)
 		if err != nil {
 			return dbList, errors.Wrap(err, "marshal")
 		}
 
-		err = json.Unmarshal(b, &p.conf)
+		psmdb := p.newCluster()
+		err = json.Unmarshal(b, psmdb)
 		if err != nil {
 			return dbList, errors.Wrap(err, "unmarshal psmdb object")
 		}
-		psmdb := p.conf
-		db := dbaas.DB{
-			ResourceName: psmdb.GetName(),
-			Status:       psmdb.GetStatus(),
-		}
+		db := psmdb.GetDBInfo()
+		db.Provider = provider
+		db.Engine = engine
+		db.Expires = k8s.ExpiresAt(b)
+		db.PMMServer = k8s.PMMServer(b)
+		db.OperatorVersion = operatorVersion
 		dbList = append(dbList, db)
 	}
 
@@ -236,6 +272,12 @@ func (p *PSMDB) UpdateDBCluster(name, opts, version string) error {
 }
 
 func (p *PSMDB) SetupPasswords(clusterName, rootPass string) error {
+	return p.setSecretsValues(clusterName, map[string][]byte{"MONGODB_CLUSTER_ADMIN_PASSWORD": []byte(rootPass)})
+}
+
+// setSecretsValues sets the values of the cluster users secret keeping its other values.
+// The secret is created with generated passwords if it doesn't exist, e.g. for a new cluster
+func (p *PSMDB) setSecretsValues(clusterName string, values map[string][]byte) error {
 	secretName := clusterName + "-psmdb-users-secrets"
 	ext, err := p.cmd.IsObjExists("secret", secretName)
 	if err != nil {
@@ -247,10 +289,8 @@ func (p *PSMDB) SetupPasswords(clusterName, rootPass string) error {
 		if err != nil {
 			return errors.Wrap(err, "get secrets")
 		}
-		for k := range data {
-			if k == "MONGODB_CLUSTER_ADMIN_PASSWORD" {
-				data[k] = []byte(rootPass)
-			}
+		for k, v := range values {
+			data[k] = v
 		}
 		err = p.cmd.UpdateSecrets(secretName, data)
 		if err != nil {
@@ -261,21 +301,17 @@ func (p *PSMDB) SetupPasswords(clusterName, rootPass string) error {
 	}
 
 	data["MONGODB_BACKUP_USER"] = []byte("backup")
-	data["MONGODB_BACKUP_PASSWORD"], err = generatePass()
-	if err != nil {
-		return errors.Wrap(err, "create backup users pass")
-	}
 	data["MONGODB_CLUSTER_ADMIN_USER"] = []byte("clusterAdmin")
-	data["MONGODB_CLUSTER_ADMIN_PASSWORD"] = []byte(rootPass)
 	data["MONGODB_CLUSTER_MONITOR_USER"] = []byte("clusterMonitor")
-	data["MONGODB_CLUSTER_MONITOR_PASSWORD"], err = generatePass()
-	if err != nil {
-		return errors.Wrap(err, "create cluster monitor users pass")
-	}
 	data["MONGODB_USER_ADMIN_USER"] = []byte("userAdmin")
-	data["MONGODB_USER_ADMIN_PASSWORD"], err = generatePass()
-	if err != nil {
-		return errors.Wrap(err, "create admin users pass")
+	for _, key := range []string{"MONGODB_BACKUP_PASSWORD", "MONGODB_CLUSTER_ADMIN_PASSWORD", "MONGODB_CLUSTER_MONITOR_PASSWORD", "MONGODB_USER_ADMIN_PASSWORD"} {
+		data[key], err = generatePass()
+		if err != nil {
+			return errors.Wrapf(err, "create %s password", key)
+		}
+	}
+	for k, v := range values {
+		data[k] = v
 	}
 
 	err = p.cmd.CreateSecret(secretName, data)
@@ -322,6 +358,65 @@ func (p *PSMDB) PreCheck(name, opts, version string) ([]string, error) {
 	return p.cmd.PreCheck(name, version, p.operatorName(), p.conf.GetOperatorImage(), "psmdb", supportedVersions)
 }
 
+// SupportBundle writes gzipped tar archive with the cluster data for troubleshooting to w
+func (p *PSMDB) SupportBundle(name string, w io.Writer) error {
+	return p.cmd.SupportBundle("psmdb", p.operatorName(), name, []string{name + "-psmdb-users-secrets"}, w)
+}
+
+// StreamLogs writes logs of the cluster components to w
+func (p *PSMDB) StreamLogs(name string, opts dbaas.LogOptions, w io.Writer) error {
+	selector := "app.kubernetes.io/instance=" + name
+	container := ""
+	switch opts.Component {
+	case "":
+	case "mongod", "arbiter":
+		selector += ",app.kubernetes.io/component=" + opts.Component
+	case "backup":
+		// backup agent runs as a sidecar of mongod pods
+		selector += ",app.kubernetes.io/component=mongod"
+		container = "backup-agent"
+	case "operator":
+		selector = k8s.OperatorSelector(p.operatorName())
+	default:
+		return errors.Errorf("unknown component '%s', use one of: mongod, arbiter, backup, operator", opts.Component)
+	}
+
+	err := p.cmd.StreamLogs(selector, container, opts.Follow, opts.Since, w)
+	if err == k8s.ErrNotFound {
+		return errors.Errorf("no pods found for cluster %s", name)
+	}
+
+	return err
+}
+
+// Diagnose checks the cluster and its environment and returns found problems
+func (p *PSMDB) Diagnose(name string) ([]dbaas.Finding, error) {
+	err := p.setVersionObjectsWithDefaults(Version(""))
+	if err != nil {
+		return nil, errors.Wrap(err, "version check")
+	}
+	cluster, err := p.cmd.GetObject("psmdb", name)
+	if err != nil {
+		return nil, errors.Wrap(err, "get cluster object")
+	}
+	st := p.conf
+	err = json.Unmarshal(cluster, st)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal object")
+	}
+
+	var findings []dbaas.Finding
+	for _, rs := range st.GetDBInfo().Replsets {
+		findings = append(findings, k8s.QuorumFindings("replset "+rs.Name, rs.Nodes)...)
+	}
+	k8sFindings, err := p.cmd.Diagnose(p.operatorName(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(findings, k8sFindings...), nil
+}
+
 func (p *PSMDB) checkClusterPods(name string) error {
 	podsData, err := p.cmd.GetObjectByLables("pods", "app.kubernetes.io/instance="+name+",app.kubernetes.io/component=mongod")
 	if err != nil {
@@ -345,3 +440,13 @@ func (p *PSMDB) checkClusterPods(name string) error {
 
 	return nil
 }
+
+// deployedOperatorVersion returns version of the running operator or empty string if it is unknown
+func (p *PSMDB) deployedOperatorVersion() string {
+	version, err := p.cmd.GetOperatorVersion(p.operatorName())
+	if err != nil {
+		return ""
+	}
+
+	return version
+}
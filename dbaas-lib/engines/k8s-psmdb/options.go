@@ -1,8 +1,14 @@
 package psmdb
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/k8s"
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
 )
 
@@ -14,3 +20,67 @@ func (p *PSMDB) ParseOptions(opts string) error {
 
 	return nil
 }
+
+// ListOptions returns options of the cluster object of the given version with their defaults
+func (p *PSMDB) ListOptions(version string) ([]options.Option, error) {
+	if len(version) == 0 {
+		version = defaultVersion
+	}
+	obj, ok := objects[Version(version)]
+	if !ok {
+		return nil, errors.Errorf("unsupported version %s", version)
+	}
+	cluster := reflect.New(reflect.TypeOf(obj.psmdb).Elem()).Interface().(PSMDBCluster)
+	err := cluster.SetDefaults()
+	if err != nil {
+		return nil, errors.Wrap(err, "set defaults")
+	}
+
+	return options.List(cluster), nil
+}
+
+// ClusterOptions returns the options of the cluster object which apply the given settings
+func (p *PSMDB) ClusterOptions(s dbaas.ClusterSettings) string {
+	var opts []string
+	if s.Size > 0 {
+		opts = append(opts, fmt.Sprintf("spec.replsets.size=%d", s.Size))
+	}
+	if len(s.StorageSize) > 0 {
+		opts = append(opts, "spec.replsets.volumeSpec.persistentVolumeClaim.resources.requests[storage]="+s.StorageSize)
+	}
+	if len(s.StorageClass) > 0 {
+		opts = append(opts, "spec.replsets.volumeSpec.persistentVolumeClaim.storageClassName="+s.StorageClass)
+	}
+	if len(s.CPU) > 0 {
+		opts = append(opts, "spec.replsets.resources.requests.cpu="+s.CPU)
+	}
+	if len(s.Memory) > 0 {
+		opts = append(opts, "spec.replsets.resources.requests.memory="+s.Memory)
+	}
+	if len(s.CPULimit) > 0 {
+		opts = append(opts, "spec.replsets.resources.limits.cpu="+s.CPULimit)
+	}
+	if len(s.MemoryLimit) > 0 {
+		opts = append(opts, "spec.replsets.resources.limits.memory="+s.MemoryLimit)
+	}
+	if len(s.Expose) > 0 {
+		opts = append(opts, "spec.replsets.expose.enabled=true", "spec.replsets.expose.exposeType="+s.ServiceType())
+	}
+	if len(s.Labels) > 0 || len(s.Annotations) > 0 {
+		opts = append(opts, k8s.MetadataOptions(s.Labels, s.Annotations))
+	}
+	if s.TTL > 0 {
+		opts = append(opts, k8s.ExpiryOption(s.TTL))
+	}
+	if s.Encryption.Enabled {
+		opts = append(opts, "spec.mongod.security.enableEncryption=true")
+		if len(s.Encryption.KeySecret) > 0 {
+			opts = append(opts, "spec.mongod.security.encryptionKeySecret="+s.Encryption.KeySecret)
+		}
+	}
+	if len(s.PMMServer) > 0 {
+		opts = append(opts, "spec.pmm.enabled=true", "spec.pmm.serverHost="+options.Quote(s.PMMServer))
+	}
+
+	return strings.Join(opts, ",")
+}
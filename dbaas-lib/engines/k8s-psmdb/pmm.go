@@ -0,0 +1,14 @@
+package psmdb
+
+// SetPMMCredentials stores the PMM server user and password in the cluster users secret
+func (p *PSMDB) SetPMMCredentials(name, user, password string) error {
+	values := make(map[string][]byte)
+	if len(user) > 0 {
+		values["PMM_SERVER_USER"] = []byte(user)
+	}
+	if len(password) > 0 {
+		values["PMM_SERVER_PASSWORD"] = []byte(password)
+	}
+
+	return p.setSecretsValues(name, values)
+}
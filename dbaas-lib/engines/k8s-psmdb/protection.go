@@ -0,0 +1,11 @@
+package psmdb
+
+// SetDeletionProtection enables or disables the deletion protection of the cluster
+func (p *PSMDB) SetDeletionProtection(name string, enabled bool) error {
+	return p.cmd.SetDeletionProtection("psmdb", name, enabled)
+}
+
+// DeletionProtected returns true if the cluster is protected from deletion
+func (p *PSMDB) DeletionProtected(name string) (bool, error) {
+	return p.cmd.DeletionProtected("psmdb", name)
+}
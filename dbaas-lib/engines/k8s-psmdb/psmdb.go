@@ -1,8 +1,7 @@
 package psmdb
 
 import (
-	"fmt"
-	"os"
+	"reflect"
 
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
 	v110 "github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/engines/k8s-psmdb/types/v110"
@@ -26,13 +25,9 @@ var objects map[Version]VersionObject
 
 func init() {
 	// Register psmdb engine in dbaas
-	psmdb, err := NewPSMDBController("", "k8s")
-	if err != nil {
-		fmt.Println("Cant start. Setup your kubectl")
-		os.Exit(1)
-	}
-
-	dbaas.RegisterEngine(provider, engine, psmdb)
+	dbaas.RegisterEngine(provider, engine, func(env dbaas.Environment) (dbaas.Engine, error) {
+		return NewPSMDBController(env, provider)
+	})
 
 	// Register psmdb versions
 	objects = make(map[Version]VersionObject)
@@ -64,10 +59,9 @@ func init() {
 
 // PSMDB represents PSMDB Operator controller
 type PSMDB struct {
-	cmd          *k8s.Cmd
-	conf         PSMDBCluster
-	platformType k8s.PlatformType
-	bundle       []k8s.BundleObject
+	cmd    *k8s.Cmd
+	conf   PSMDBCluster
+	bundle []k8s.BundleObject
 }
 
 type VersionObject struct {
@@ -76,15 +70,15 @@ type VersionObject struct {
 }
 
 // NewPSMDBController returns new PSMDBOperator Controller
-func NewPSMDBController(envCrt, provider string) (*PSMDB, error) {
+func NewPSMDBController(env dbaas.Environment, provider string) (*PSMDB, error) {
 	var psmdb PSMDB
 	if len(provider) == 0 || provider == "k8s" {
-		k8sCmd, err := k8s.New(envCrt)
+		k8sCmd, err := k8s.New(env.Name, env.KubeContext)
 		if err != nil {
 			return nil, errors.Wrap(err, "new Cmd")
 		}
+		k8sCmd.Namespace = env.Namespace
 		psmdb.cmd = k8sCmd
-		psmdb.platformType = k8sCmd.GetPlatformType()
 	}
 	return &psmdb, nil
 }
@@ -103,13 +97,18 @@ func (p *PSMDB) setVersionObjectsWithDefaults(version Version) error {
 	p.conf = objects[version].psmdb
 	err := p.conf.SetDefaults()
 	if err != nil {
-		errors.Wrap(err, "set defaults")
+		return errors.Wrap(err, "set defaults")
 	}
 	p.bundle = objects[version].k8s.Bundle
 
 	return nil
 }
 
+// newCluster returns an empty cluster object of the same version as the current config
+func (p *PSMDB) newCluster() PSMDBCluster {
+	return reflect.New(reflect.TypeOf(p.conf).Elem()).Interface().(PSMDBCluster)
+}
+
 func (p PSMDB) getCR(cluster PSMDBCluster) (string, error) {
 	return cluster.GetCR()
 }
@@ -0,0 +1,107 @@
+package psmdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/k8s"
+)
+
+const healthyMembersEval = "rs.status().members.filter(function(m) { return m.health == 1 && (m.state == 1 || m.state == 2) }).length"
+
+// ScaleDBCluster checks that the nodes have enough resources for the new members of the replset and sets the replset size
+func (p *PSMDB) ScaleDBCluster(name string, s dbaas.Scale) ([]string, error) {
+	if s.Proxies > 0 {
+		return nil, errors.New("proxies can't be scaled for MongoDB cluster")
+	}
+	err := p.setVersionObjectsWithDefaults(Version(""))
+	if err != nil {
+		return nil, errors.Wrap(err, "version check")
+	}
+	oldCR, err := p.cmd.GetObject("psmdb", name)
+	if err != nil {
+		return nil, errors.Wrap(err, "get cluster cr")
+	}
+	err = json.Unmarshal(oldCR, &p.conf)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal cr")
+	}
+	replset, err := p.replset(s.Replset)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := k8s.ComponentSelector(name, "mongod") + ",app.kubernetes.io/replset=" + replset.Name
+	warns, err := p.cmd.CheckCapacity(selector, int(s.Nodes-replset.Nodes.Desired))
+	if err != nil {
+		return warns, errors.Wrap(err, "check mongod capacity")
+	}
+
+	err = p.UpdateDBCluster(name, fmt.Sprintf("spec.replsets[name=%s].size=%d", replset.Name, s.Nodes), "")
+	if err != nil {
+		return warns, errors.Wrap(err, "update cluster")
+	}
+
+	return warns, nil
+}
+
+// ClusterMembers returns the number of the healthy primary and secondary members of the replset
+func (p *PSMDB) ClusterMembers(name, replset string) (int32, error) {
+	if len(replset) == 0 {
+		err := p.setVersionObjectsWithDefaults(Version(""))
+		if err != nil {
+			return 0, errors.Wrap(err, "version check")
+		}
+		cr, err := p.cmd.GetObject("psmdb", name)
+		if err != nil {
+			return 0, errors.Wrap(err, "get cluster cr")
+		}
+		err = json.Unmarshal(cr, &p.conf)
+		if err != nil {
+			return 0, errors.Wrap(err, "unmarshal cr")
+		}
+		rs, err := p.replset("")
+		if err != nil {
+			return 0, err
+		}
+		replset = rs.Name
+	}
+
+	out, err := p.cmd.Exec(name+"-"+replset+"-0", "mongod", "sh", "-c",
+		`mongo admin --quiet -u "$MONGODB_CLUSTER_ADMIN_USER" -p "$MONGODB_CLUSTER_ADMIN_PASSWORD" --eval "`+healthyMembersEval+`"`)
+	if err != nil {
+		return 0, errors.Wrap(err, "get replset status")
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	members, err := strconv.ParseInt(strings.TrimSpace(lines[len(lines)-1]), 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parse replset status: %s", out)
+	}
+
+	return int32(members), nil
+}
+
+// replset returns the replset of the loaded cluster with the given name, the first replset is returned if the name is empty
+func (p *PSMDB) replset(name string) (dbaas.Replset, error) {
+	replsets := p.conf.GetDBInfo().Replsets
+	if len(replsets) == 0 {
+		return dbaas.Replset{}, errors.New("cluster has no replsets")
+	}
+	if len(name) == 0 {
+		return replsets[0], nil
+	}
+	var names []string
+	for _, rs := range replsets {
+		if rs.Name == name {
+			return rs, nil
+		}
+		names = append(names, rs.Name)
+	}
+
+	return dbaas.Replset{}, errors.Errorf("replset %s not found, use one of: %s", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,59 @@
+package psmdb
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
+)
+
+// SetupSecurity creates the TLS secrets of the replset services.
+// The operator generates the encryption key if the key secret doesn't exist
+func (p *PSMDB) SetupSecurity(name, opts, version string, tls dbaas.TLS, encryption dbaas.Encryption) error {
+	if len(tls.Mode) == 0 {
+		return nil
+	}
+
+	services, err := p.tlsServices(name, opts, version)
+	if err != nil {
+		return errors.Wrap(err, "get services")
+	}
+	err = p.cmd.SetupTLS(name, tls, services)
+	if err != nil {
+		return errors.Wrap(err, "setup TLS")
+	}
+
+	return nil
+}
+
+// DeleteSecurity deletes the TLS secrets and certificates created by SetupSecurity
+func (p *PSMDB) DeleteSecurity(name string, tls dbaas.TLS) error {
+	return p.cmd.DeleteTLS(name, tls)
+}
+
+// tlsServices returns names of the services of the cluster with the given options.
+// The options are parsed into a new object so the cluster config isn't changed
+func (p *PSMDB) tlsServices(name, opts, version string) ([]string, error) {
+	err := p.setVersionObjectsWithDefaults(Version(version))
+	if err != nil {
+		return nil, errors.Wrap(err, "version check")
+	}
+	cluster := p.newCluster()
+	err = cluster.SetDefaults()
+	if err != nil {
+		return nil, errors.Wrap(err, "set defaults")
+	}
+	err = options.Parse(&cluster, reflect.TypeOf(cluster), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse opts")
+	}
+
+	var services []string
+	for _, rs := range cluster.GetReplestsNames() {
+		services = append(services, name+"-"+rs)
+	}
+
+	return services, nil
+}
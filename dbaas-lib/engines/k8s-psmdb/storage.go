@@ -0,0 +1,62 @@
+package psmdb
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/k8s"
+)
+
+// ResizeStorage expands the mongod volumes of every replset to the given size and sets the size in the cluster CR.
+// If the volumes can't be expanded in place only the CR is updated and the steps of the volumes replacement are returned
+func (p *PSMDB) ResizeStorage(name, size string) (dbaas.StorageResize, error) {
+	err := p.setVersionObjectsWithDefaults(Version(""))
+	if err != nil {
+		return dbaas.StorageResize{}, errors.Wrap(err, "version check")
+	}
+	oldCR, err := p.cmd.GetObject("psmdb", name)
+	if err != nil {
+		return dbaas.StorageResize{}, errors.Wrap(err, "get cluster cr")
+	}
+	err = json.Unmarshal(oldCR, &p.conf)
+	if err != nil {
+		return dbaas.StorageResize{}, errors.Wrap(err, "unmarshal cr")
+	}
+	var opts []string
+	for _, rs := range p.conf.GetReplestsNames() {
+		opts = append(opts, "spec.replsets[name="+rs+"].volumeSpec.persistentVolumeClaim.resources.requests[storage]="+size)
+	}
+
+	selector := k8s.ComponentSelector(name, "mongod")
+	res := dbaas.StorageResize{Expanded: true}
+	err = p.cmd.ExpandVolumes(selector, size)
+	if notSupported, ok := errors.Cause(err).(k8s.ErrExpansionNotSupported); ok {
+		res.Expanded = false
+		res.Reason = notSupported.Error()
+		res.Steps, err = p.cmd.VolumeReplacementSteps(selector)
+		if err != nil {
+			return res, errors.Wrap(err, "get replacement steps")
+		}
+	} else if err != nil {
+		return res, errors.Wrap(err, "expand volumes")
+	}
+
+	err = p.UpdateDBCluster(name, strings.Join(opts, ","), "")
+	if err != nil {
+		return res, errors.Wrap(err, "update cluster")
+	}
+	err = p.cmd.RecreateStatefulSets(selector)
+	if err != nil {
+		return res, errors.Wrap(err, "recreate statefulsets")
+	}
+
+	return res, nil
+}
+
+// VolumesResizeState returns resize state of the mongod volumes
+func (p *PSMDB) VolumesResizeState(name string) ([]dbaas.VolumeResize, error) {
+	return p.cmd.VolumesResizeState(k8s.ComponentSelector(name, "mongod"))
+}
@@ -90,10 +90,72 @@ func (cr *PerconaServerMongoDB) GetStatus() dbaas.State {
 	return dbaas.State(cr.Status.Status)
 }
 
+// GetDBInfo returns cluster topology, storage, images and resources
+func (cr *PerconaServerMongoDB) GetDBInfo() dbaas.DB {
+	db := dbaas.DB{
+		ResourceName: cr.ObjectMeta.Name,
+		Namespace:    cr.ObjectMeta.Namespace,
+		Status:       dbaas.State(cr.Status.Status),
+		Images:       map[string]string{"mongod": cr.Spec.Image},
+		PMMEnabled:   cr.Spec.PMM.Enabled,
+		Created:      cr.ObjectMeta.CreationTimestamp.Time,
+		Labels:       cr.ObjectMeta.Labels,
+	}
+	for _, rs := range cr.Spec.Replsets {
+		replset := dbaas.Replset{
+			Name:  rs.Name,
+			Nodes: dbaas.Nodes{Desired: rs.Size},
+		}
+		if status, ok := cr.Status.Replsets[rs.Name]; ok && status != nil {
+			replset.Nodes.Ready = status.Ready
+			replset.Status = dbaas.State(status.Status)
+		}
+		if rs.Arbiter.Enabled {
+			replset.Arbiters = rs.Arbiter.Size
+		}
+		if rs.Resources != nil && rs.Resources.Requests != nil {
+			replset.CPU = rs.Resources.Requests.CPU
+			replset.Memory = rs.Resources.Requests.Memory
+		}
+		if rs.VolumeSpec != nil && rs.VolumeSpec.PersistentVolumeClaim != nil {
+			if size, ok := rs.VolumeSpec.PersistentVolumeClaim.Resources.Requests[corev1.ResourceStorage]; ok {
+				replset.Size = size.String()
+			}
+		}
+		db.Nodes.Desired += replset.Nodes.Desired
+		db.Nodes.Ready += replset.Nodes.Ready
+		db.Replsets = append(db.Replsets, replset)
+	}
+	if len(cr.Spec.Replsets) > 0 {
+		rs := cr.Spec.Replsets[0]
+		if rs.Resources != nil && rs.Resources.Requests != nil {
+			db.CPU = rs.Resources.Requests.CPU
+			db.Memory = rs.Resources.Requests.Memory
+		}
+		if rs.VolumeSpec != nil && rs.VolumeSpec.PersistentVolumeClaim != nil {
+			pvc := rs.VolumeSpec.PersistentVolumeClaim
+			if size, ok := pvc.Resources.Requests[corev1.ResourceStorage]; ok {
+				db.Size = size.String()
+			}
+			if pvc.StorageClassName != nil {
+				db.StorageClass = *pvc.StorageClassName
+			}
+		}
+	}
+	if len(cr.Spec.Backup.Image) > 0 {
+		db.Images["backup"] = cr.Spec.Backup.Image
+	}
+	if cr.Spec.PMM.Enabled {
+		db.Images["pmm"] = cr.Spec.PMM.Image
+	}
+
+	return db
+}
+
 func (cr *PerconaServerMongoDB) GetReplestsNames() []string {
 	var replsetsNames []string
-	for name := range cr.Status.Replsets {
-		replsetsNames = append(replsetsNames, name)
+	for _, rs := range cr.Spec.Replsets {
+		replsetsNames = append(replsetsNames, rs.Name)
 	}
 	return replsetsNames
 }
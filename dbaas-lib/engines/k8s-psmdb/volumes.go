@@ -0,0 +1,117 @@
+package psmdb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// OrphanedVolumes returns the volumes of the deleted PSMDB clusters
+func (p *PSMDB) OrphanedVolumes() ([]dbaas.Volume, error) {
+	return p.cmd.OrphanedVolumes("psmdb", p.operatorName())
+}
+
+// AttachVolumes creates the cluster on top of its preserved volumes with the same replsets, their sizes and storage.
+// The preserved secrets of the cluster are used so the operator can access the data with the same users
+func (p *PSMDB) AttachVolumes(name, opts, version string) error {
+	volumes, err := p.clusterVolumes(name)
+	if err != nil {
+		return err
+	}
+	ext, err := p.cmd.IsObjExists("secret", name+"-psmdb-users-secrets")
+	if err != nil {
+		return errors.Wrap(err, "check if secrets exists")
+	}
+	if !ext {
+		return errors.Errorf("secret %s-psmdb-users-secrets isn't found, the operator can't access the preserved data without the users passwords", name)
+	}
+
+	members := make(map[string]int)
+	first := make(map[string]dbaas.Volume)
+	for _, v := range volumes {
+		if v.Component != "mongod" {
+			return errors.Errorf("volume %s of %s isn't a replset volume", v.Name, v.Component)
+		}
+		if members[v.Replset] == 0 {
+			first[v.Replset] = v
+		}
+		members[v.Replset]++
+	}
+	replsets := make([]string, 0, len(members))
+	for rs := range members {
+		replsets = append(replsets, rs)
+	}
+	sort.Strings(replsets)
+
+	// the first replset of the default spec is renamed so no extra replset is added
+	attachOpts := []string{"spec.replsets[0].name=" + replsets[0]}
+	for _, rs := range replsets {
+		prefix := "spec.replsets[name=" + rs + "]"
+		attachOpts = append(attachOpts, fmt.Sprintf("%s.size=%d", prefix, members[rs]))
+		if len(first[rs].Size) > 0 {
+			attachOpts = append(attachOpts, prefix+".volumeSpec.persistentVolumeClaim.resources.requests[storage]="+first[rs].Size)
+		}
+		if len(first[rs].StorageClass) > 0 {
+			attachOpts = append(attachOpts, prefix+".volumeSpec.persistentVolumeClaim.storageClassName="+first[rs].StorageClass)
+		}
+	}
+	if len(opts) > 0 {
+		attachOpts = append(attachOpts, opts)
+	}
+
+	return p.CreateDBCluster(name, strings.Join(attachOpts, ","), "", version)
+}
+
+// PurgeVolumes deletes the preserved volumes and secrets of the deleted cluster
+func (p *PSMDB) PurgeVolumes(name string) error {
+	ext, err := p.cmd.IsObjExists("psmdb", name)
+	if err != nil {
+		return errors.Wrap(err, "check if cluster exists")
+	}
+	if ext {
+		return errors.Errorf("cluster %s exists, use delete-db to delete it with its data", name)
+	}
+	_, err = p.clusterVolumes(name)
+	if err != nil {
+		return err
+	}
+
+	err = p.cmd.DeleteVolumeClaims(p.operatorName(), name)
+	if err != nil {
+		return errors.Wrap(err, "delete volumes")
+	}
+	ext, err = p.cmd.IsObjExists("secret", name+"-psmdb-users-secrets")
+	if err != nil {
+		return errors.Wrap(err, "check if secrets exists")
+	}
+	if ext {
+		err = p.cmd.DeleteObject("secret", name+"-psmdb-users-secrets")
+		if err != nil {
+			return errors.Wrap(err, "delete secret")
+		}
+	}
+
+	return nil
+}
+
+func (p *PSMDB) clusterVolumes(name string) ([]dbaas.Volume, error) {
+	volumes, err := p.OrphanedVolumes()
+	if err != nil {
+		return nil, errors.Wrap(err, "get preserved volumes")
+	}
+	var clusterVolumes []dbaas.Volume
+	for _, v := range volumes {
+		if v.Cluster == name {
+			clusterVolumes = append(clusterVolumes, v)
+		}
+	}
+	if len(clusterVolumes) == 0 {
+		return nil, errors.Errorf("no preserved volumes of cluster %s", name)
+	}
+
+	return clusterVolumes, nil
+}
@@ -32,4 +32,5 @@ type PXDBCluster interface {
 	GetStatus() dbaas.State
 	GetPXCStatus() string
 	GetStatusHost() string
+	GetDBInfo() dbaas.DB
 }
@@ -3,6 +3,7 @@ package pxc
 import (
 	"crypto/rand"
 	"encoding/json"
+	"io"
 	"math/big"
 	mrand "math/rand"
 	"strings"
@@ -27,7 +28,7 @@ func (p *PXC) CreateDBCluster(name, opts, rootPass, version string) error {
 
 	p.conf.SetName(name)
 	p.conf.SetUsersSecretName(name)
-	switch p.platformType {
+	switch p.cmd.GetPlatformType() {
 	case k8s.PlatformMinishift, k8s.PlatformMinikube:
 		p.conf.SetupMiniConfig()
 	}
@@ -93,6 +94,14 @@ func (p *PXC) DeleteDBCluster(name, opts, version string, delePVC bool) (string,
 		}
 		return "pvc/" + pvc.Name, nil
 	}
+	// backups of the cluster can't be restored without the users secret
+	hasBackups, err := p.cmd.HasBackups(backupObjects, name)
+	if err != nil {
+		return "", errors.Wrap(err, "check backups")
+	}
+	if hasBackups {
+		return "", nil
+	}
 	err = p.cmd.DeleteObject("secret", name+"-secrets")
 	if err != nil {
 		return "", errors.Wrap(err, "delete secret")
@@ -134,8 +143,20 @@ func (p *PXC) GetDBCluster(name, opts string) (dbaas.DB, error) {
 		return db, errors.Wrap(err, "get namspace name")
 	}
 
+	db = st.GetDBInfo()
 	db.Provider = provider
 	db.Engine = engine
+	db.Expires = k8s.ExpiresAt(cluster)
+	db.PMMServer = k8s.PMMServer(cluster)
+	db.TLS, err = p.cmd.IsObjExists("secret", k8s.TLSSecret(cluster, name, "sslSecretName"))
+	if err != nil {
+		return db, errors.Wrap(err, "check TLS secret")
+	}
+	sslMode := ""
+	if db.TLS {
+		sslMode = " --ssl-mode=REQUIRED"
+	}
+	db.OperatorVersion = p.deployedOperatorVersion()
 	db.ResourceName = name
 	db.Port = 3306
 	db.User = "root"
@@ -159,26 +180,35 @@ func (p *PXC) GetDBCluster(name, opts string) (dbaas.DB, error) {
 			}
 		}
 		if st.GetStatus() == dbaas.StateReady {
-			db.Message = "To access database please run the following command:\nmysql -h " + db.ResourceEndpoint + " -P 3306 -uroot -pPASSWORD"
+			db.Message = "To access database please run the following command:\nmysql -h " + db.ResourceEndpoint + " -P 3306 -uroot -pPASSWORD" + sslMode
 		}
 		return db, nil
 	}
 
 	if st.GetStatus() == dbaas.StateReady {
-		db.Message = "To access database please run the following commands:\nkubectl port-forward svc/" + name + "-proxysql 3306:3306 &\nmysql -h 127.0.0.1 -P 3306 -uroot -pPASSWORD"
+		db.Message = "To access database please run the following commands:\nkubectl port-forward svc/" + name + "-proxysql 3306:3306 &\nmysql -h 127.0.0.1 -P 3306 -uroot -pPASSWORD" + sslMode
 	}
 	if st.GetStatus() == dbaas.StateUnknown && st.GetPXCStatus() == string(dbaas.StateReady) {
 		db.Status = dbaas.StateReady
-		db.Message = "To access database please run the following commands:\nkubectl port-forward pod/" + name + "-pxc-0 3306:3306 &\nmysql -h 127.0.0.1 -P 3306 -uroot -pPASSWORD"
+		db.Message = "To access database please run the following commands:\nkubectl port-forward pod/" + name + "-pxc-0 3306:3306 &\nmysql -h 127.0.0.1 -P 3306 -uroot -pPASSWORD" + sslMode
 	}
 
 	return db, nil
 }
 
 // GetDBClusterList return list of existing DB obkects
-func (p *PXC) GetDBClusterList() ([]dbaas.DB, error) {
+func (p *PXC) GetDBClusterList(allNamespaces bool) ([]dbaas.DB, error) {
 	var dbList []dbaas.DB
-	cluster, err := p.cmd.GetObjects("pxc")
+	var cluster []byte
+	var err error
+	if allNamespaces {
+		cluster, err = p.cmd.GetObjectsInAllNamespaces("pxc")
+	} else {
+		cluster, err = p.cmd.GetObjects("pxc")
+	}
+	if err == k8s.ErrNotFound {
+		return dbList, nil
+	}
 	if err != nil {
 		return dbList, errors.Wrap(err, "get cluster object")
 
@@ -192,21 +222,24 @@ func (p *PXC) GetDBClusterList() ([]dbaas.DB, error) {
 	if err != nil {
 		return dbList, errors.Wrap(err, "version check")
 	}
+	operatorVersion := p.deployedOperatorVersion()
 	for _, c := range st.Items {
 		b, err := json.Marshal(c)
 		if err != nil {
 			return dbList, errors.Wrap(err, "marshal")
 		}
 
-		err = json.Unmarshal(b, &p.conf)
+		pxc := p.newCluster()
+		err = json.Unmarshal(b, pxc)
 		if err != nil {
 			return dbList, errors.Wrap(err, "unmarshal pxc object")
 		}
-		pxc := p.conf
-		db := dbaas.DB{
-			ResourceName: pxc.GetName(),
-			Status:       pxc.GetStatus(),
-		}
+		db := pxc.GetDBInfo()
+		db.Provider = provider
+		db.Engine = engine
+		db.Expires = k8s.ExpiresAt(b)
+		db.PMMServer = k8s.PMMServer(b)
+		db.OperatorVersion = operatorVersion
 		dbList = append(dbList, db)
 	}
 
@@ -250,6 +283,12 @@ func (p *PXC) UpdateDBCluster(name, opts, version string) error {
 }
 
 func (p *PXC) SetupPasswords(clusterName, rootPass string) error {
+	return p.setSecretsValues(clusterName, map[string][]byte{"root": []byte(rootPass)})
+}
+
+// setSecretsValues sets the values of the cluster users secret keeping its other values.
+// The secret is created with generated passwords if it doesn't exist, e.g. for a new cluster
+func (p *PXC) setSecretsValues(clusterName string, values map[string][]byte) error {
 	secretName := clusterName + "-secrets"
 	ext, err := p.cmd.IsObjExists("secret", secretName)
 	if err != nil {
@@ -261,10 +300,8 @@ func (p *PXC) SetupPasswords(clusterName, rootPass string) error {
 		if err != nil {
 			return errors.Wrap(err, "get secrets")
 		}
-		for k := range data {
-			if k == "root" {
-				data[k] = []byte(rootPass)
-			}
+		for k, v := range values {
+			data[k] = v
 		}
 		err = p.cmd.UpdateSecrets(secretName, data)
 		if err != nil {
@@ -274,22 +311,14 @@ func (p *PXC) SetupPasswords(clusterName, rootPass string) error {
 		return nil
 	}
 
-	data["root"] = []byte(rootPass)
-	data["xtrabackup"], err = generatePass()
-	if err != nil {
-		return errors.Wrap(err, "create xtrabackup users password")
-	}
-	data["monitor"], err = generatePass()
-	if err != nil {
-		return errors.Wrap(err, "create monitor users password")
-	}
-	data["clustercheck"], err = generatePass()
-	if err != nil {
-		return errors.Wrap(err, "create clustercheck users password")
+	for _, key := range []string{"root", "xtrabackup", "monitor", "clustercheck", "proxyadmin"} {
+		data[key], err = generatePass()
+		if err != nil {
+			return errors.Wrapf(err, "create %s password", key)
+		}
 	}
-	data["proxyadmin"], err = generatePass()
-	if err != nil {
-		return errors.Wrap(err, "create proxyadmin users password")
+	for k, v := range values {
+		data[k] = v
 	}
 
 	err = p.cmd.CreateSecret(secretName, data)
@@ -336,6 +365,68 @@ func (p *PXC) PreCheck(name, opts, version string) ([]string, error) {
 	return p.cmd.PreCheck(name, string(version), p.operatorName(), p.conf.GetOperatorImage(), "pxc", supportedVersions)
 }
 
+// SupportBundle writes gzipped tar archive with the cluster data for troubleshooting to w
+func (p *PXC) SupportBundle(name string, w io.Writer) error {
+	return p.cmd.SupportBundle("pxc", p.operatorName(), name, []string{name + "-secrets"}, w)
+}
+
+// StreamLogs writes logs of the cluster components to w
+func (p *PXC) StreamLogs(name string, opts dbaas.LogOptions, w io.Writer) error {
+	selector := "app.kubernetes.io/instance=" + name
+	switch opts.Component {
+	case "":
+	case "pxc", "proxysql":
+		selector += ",app.kubernetes.io/component=" + opts.Component
+	case "backup":
+		selector = "type=xtrabackup,cluster=" + name
+	case "operator":
+		selector = k8s.OperatorSelector(p.operatorName())
+	default:
+		return errors.Errorf("unknown component '%s', use one of: pxc, proxysql, backup, operator", opts.Component)
+	}
+
+	err := p.cmd.StreamLogs(selector, "", opts.Follow, opts.Since, w)
+	if err == k8s.ErrNotFound {
+		return errors.Errorf("no pods found for cluster %s", name)
+	}
+
+	return err
+}
+
+// Diagnose checks the cluster and its environment and returns found problems
+func (p *PXC) Diagnose(name string) ([]dbaas.Finding, error) {
+	err := p.setVersionObjectsWithDefaults(Version(""))
+	if err != nil {
+		return nil, errors.Wrap(err, "version check")
+	}
+	cluster, err := p.cmd.GetObject("pxc", name)
+	if err != nil {
+		return nil, errors.Wrap(err, "get cluster object")
+	}
+	st := p.conf
+	err = json.Unmarshal(cluster, st)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal object")
+	}
+
+	db := st.GetDBInfo()
+	findings := k8s.QuorumFindings("pxc", db.Nodes)
+	if len(db.Proxy) > 0 && db.ProxyNodes.Desired > 0 && db.ProxyNodes.Ready == 0 {
+		findings = append(findings, dbaas.Finding{
+			Severity:  dbaas.SeverityCritical,
+			Component: db.Proxy,
+			Problem:   "no proxy instances are ready, the cluster is not reachable through the service",
+			Hint:      "check the proxysql pods with 'kubectl describe pod -l app.kubernetes.io/component=proxysql,app.kubernetes.io/instance=" + name + "'",
+		})
+	}
+	k8sFindings, err := p.cmd.Diagnose(p.operatorName(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(findings, k8sFindings...), nil
+}
+
 func getOperatorImageVersion(image string) (string, error) {
 	imageArr := strings.Split(image, ":")
 	if len(imageArr) < 2 {
@@ -355,7 +446,7 @@ func (p *PXC) checkClusterPods(name string) error {
 		return err
 	}
 
-	podsData, err = p.cmd.GetObjectByLables("pods", "app.kubernetes.io/instance=cluster1,app.kubernetes.io/component=proxysql")
+	podsData, err = p.cmd.GetObjectByLables("pods", "app.kubernetes.io/instance="+name+",app.kubernetes.io/component=proxysql")
 	if err != nil {
 		return errors.Wrap(err, "get pods")
 	}
@@ -405,6 +496,16 @@ func (p *PXC) getNamespace() (string, error) {
 	return ns, nil
 }
 
+// deployedOperatorVersion returns version of the running operator or empty string if it is unknown
+func (p *PXC) deployedOperatorVersion() string {
+	version, err := p.cmd.GetOperatorVersion(p.operatorName())
+	if err != nil {
+		return ""
+	}
+
+	return version
+}
+
 func (p *PXC) getOperatorVersion() string {
 	imageArr := strings.Split(p.conf.GetOperatorImage(), ":")
 	if len(imageArr) > 1 {
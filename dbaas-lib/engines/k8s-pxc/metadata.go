@@ -0,0 +1,34 @@
+package pxc
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// secretsTimeout is the time the operator has to generate the secrets of the new cluster
+const secretsTimeout = 2 * time.Minute
+
+// SetSecretsMetadata adds the labels and annotations to the users and TLS secrets of the cluster
+func (p *PXC) SetSecretsMetadata(name string, labels, annotations map[string]string) error {
+	usersSecret := name + "-secrets"
+	err := p.cmd.WaitObject("secret", usersSecret, secretsTimeout)
+	if err != nil {
+		return errors.Wrap(err, "wait users secret")
+	}
+	for _, secret := range []string{usersSecret, name + "-ssl", name + "-ssl-internal"} {
+		ext, err := p.cmd.IsObjExists("secret", secret)
+		if err != nil {
+			return errors.Wrapf(err, "check if secret %s exists", secret)
+		}
+		if !ext {
+			continue
+		}
+		err = p.cmd.SetMetadata("secret", secret, labels, annotations)
+		if err != nil {
+			return errors.Wrapf(err, "set secret %s metadata", secret)
+		}
+	}
+
+	return nil
+}
@@ -1,8 +1,14 @@
 package pxc
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/k8s"
 	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
 )
 
@@ -15,3 +21,67 @@ func (p *PXC) ParseOptions(opts string) error {
 
 	return nil
 }
+
+// ListOptions returns options of the cluster object of the given version with their defaults
+func (p *PXC) ListOptions(version string) ([]options.Option, error) {
+	if len(version) == 0 {
+		version = string(defaultVersion)
+	}
+	obj, ok := objects[Version(version)]
+	if !ok {
+		return nil, errors.Errorf("unsupported version %s", version)
+	}
+	cluster := reflect.New(reflect.TypeOf(obj.pxc).Elem()).Interface().(PXDBCluster)
+	err := cluster.SetDefaults()
+	if err != nil {
+		return nil, errors.Wrap(err, "set defaults")
+	}
+
+	return options.List(cluster), nil
+}
+
+// ClusterOptions returns the options of the cluster object which apply the given settings
+func (p *PXC) ClusterOptions(s dbaas.ClusterSettings) string {
+	var opts []string
+	if s.Size > 0 {
+		opts = append(opts, fmt.Sprintf("spec.pxc.size=%d", s.Size))
+	}
+	if len(s.StorageSize) > 0 {
+		opts = append(opts, "spec.pxc.volumeSpec.persistentVolumeClaim.resources.requests[storage]="+s.StorageSize)
+	}
+	if len(s.StorageClass) > 0 {
+		opts = append(opts, "spec.pxc.volumeSpec.persistentVolumeClaim.storageClassName="+s.StorageClass)
+	}
+	if len(s.CPU) > 0 {
+		opts = append(opts, "spec.pxc.resources.requests.cpu="+s.CPU)
+	}
+	if len(s.Memory) > 0 {
+		opts = append(opts, "spec.pxc.resources.requests.memory="+s.Memory)
+	}
+	if len(s.CPULimit) > 0 {
+		opts = append(opts, "spec.pxc.resources.limits.cpu="+s.CPULimit)
+	}
+	if len(s.MemoryLimit) > 0 {
+		opts = append(opts, "spec.pxc.resources.limits.memory="+s.MemoryLimit)
+	}
+	if len(s.Expose) > 0 {
+		opts = append(opts, "spec.proxysql.enabled=true", "spec.proxysql.serviceType="+s.ServiceType())
+	}
+	if len(s.Labels) > 0 || len(s.Annotations) > 0 {
+		opts = append(opts, k8s.MetadataOptions(s.Labels, s.Annotations))
+	}
+	if s.TTL > 0 {
+		opts = append(opts, k8s.ExpiryOption(s.TTL))
+	}
+	if s.Encryption.Enabled && len(s.Encryption.KeySecret) > 0 {
+		opts = append(opts, "spec.vaultSecretName="+s.Encryption.KeySecret)
+	}
+	if len(s.PMMServer) > 0 {
+		opts = append(opts, "spec.pmm.enabled=true", "spec.pmm.serverHost="+options.Quote(s.PMMServer))
+	}
+	if len(s.PMMUser) > 0 {
+		opts = append(opts, "spec.pmm.serverUser="+options.Quote(s.PMMUser))
+	}
+
+	return strings.Join(opts, ",")
+}
@@ -0,0 +1,11 @@
+package pxc
+
+// SetPMMCredentials stores the PMM server password in the cluster secrets.
+// The PMM user is a cluster object option so it isn't stored in the secrets
+func (p *PXC) SetPMMCredentials(name, user, password string) error {
+	if len(password) == 0 {
+		return nil
+	}
+
+	return p.setSecretsValues(name, map[string][]byte{"pmmserver": []byte(password)})
+}
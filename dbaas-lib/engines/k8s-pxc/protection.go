@@ -0,0 +1,11 @@
+package pxc
+
+// SetDeletionProtection enables or disables the deletion protection of the cluster
+func (p *PXC) SetDeletionProtection(name string, enabled bool) error {
+	return p.cmd.SetDeletionProtection("pxc", name, enabled)
+}
+
+// DeletionProtected returns true if the cluster is protected from deletion
+func (p *PXC) DeletionProtected(name string) (bool, error) {
+	return p.cmd.DeletionProtected("pxc", name)
+}
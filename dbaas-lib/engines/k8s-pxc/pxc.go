@@ -15,8 +15,7 @@
 package pxc
 
 import (
-	"fmt"
-	"os"
+	"reflect"
 
 	"github.com/pkg/errors"
 
@@ -40,12 +39,9 @@ var objects map[Version]VersionObject
 
 func init() {
 	// Register pxc engine in dbaas
-	pxc, err := NewPXCController("", "k8s")
-	if err != nil {
-		fmt.Println("Cant start. Setup your kubectl")
-		os.Exit(1)
-	}
-	dbaas.RegisterEngine(provider, engine, pxc)
+	dbaas.RegisterEngine(provider, engine, func(env dbaas.Environment) (dbaas.Engine, error) {
+		return NewPXCController(env, provider)
+	})
 
 	// Register pxc versions
 	objects = make(map[Version]VersionObject)
@@ -77,10 +73,9 @@ func init() {
 
 // PXC represents PXC Operator controller
 type PXC struct {
-	cmd          *k8s.Cmd
-	conf         PXDBCluster
-	platformType k8s.PlatformType
-	bundle       []k8s.BundleObject
+	cmd    *k8s.Cmd
+	conf   PXDBCluster
+	bundle []k8s.BundleObject
 }
 
 type VersionObject struct {
@@ -89,15 +84,15 @@ type VersionObject struct {
 }
 
 // NewPXCController returns new PXCOperator Controller
-func NewPXCController(envCrt, provider string) (*PXC, error) {
+func NewPXCController(env dbaas.Environment, provider string) (*PXC, error) {
 	var pxc PXC
 	if len(provider) == 0 || provider == "k8s" {
-		k8sCmd, err := k8s.New(envCrt)
+		k8sCmd, err := k8s.New(env.Name, env.KubeContext)
 		if err != nil {
 			return nil, errors.Wrap(err, "new Cmd")
 		}
+		k8sCmd.Namespace = env.Namespace
 		pxc.cmd = k8sCmd
-		pxc.platformType = k8sCmd.GetPlatformType()
 	}
 
 	return &pxc, nil
@@ -119,13 +114,18 @@ func (p *PXC) setVersionObjectsWithDefaults(version Version) error {
 	p.conf = objects[version].pxc
 	err := p.conf.SetDefaults()
 	if err != nil {
-		errors.Wrap(err, "set defaults")
+		return errors.Wrap(err, "set defaults")
 	}
 	p.bundle = objects[version].k8s.Bundle
 
 	return nil
 }
 
+// newCluster returns an empty cluster object of the same version as the current config
+func (p *PXC) newCluster() PXDBCluster {
+	return reflect.New(reflect.TypeOf(p.conf).Elem()).Interface().(PXDBCluster)
+}
+
 func (p PXC) getCR(cluster PXDBCluster) (string, error) {
 	return cluster.GetCR()
 }
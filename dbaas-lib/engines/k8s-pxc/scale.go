@@ -0,0 +1,71 @@
+package pxc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/k8s"
+)
+
+// ScaleDBCluster checks that the nodes have enough resources for the new PXC and ProxySQL pods and sets their number
+func (p *PXC) ScaleDBCluster(name string, s dbaas.Scale) ([]string, error) {
+	err := p.setVersionObjectsWithDefaults(Version(""))
+	if err != nil {
+		return nil, errors.Wrap(err, "version check")
+	}
+	oldCR, err := p.cmd.GetObject("pxc", name)
+	if err != nil {
+		return nil, errors.Wrap(err, "get cluster cr")
+	}
+	err = json.Unmarshal(oldCR, &p.conf)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal cr")
+	}
+	db := p.conf.GetDBInfo()
+
+	warns, err := p.cmd.CheckCapacity(k8s.ComponentSelector(name, "pxc"), int(s.Nodes-db.Nodes.Desired))
+	if err != nil {
+		return warns, errors.Wrap(err, "check pxc capacity")
+	}
+	opts := []string{fmt.Sprintf("spec.pxc.size=%d", s.Nodes)}
+	if s.Proxies > 0 {
+		proxyWarns, err := p.cmd.CheckCapacity(k8s.ComponentSelector(name, "proxysql"), int(s.Proxies-db.ProxyNodes.Desired))
+		warns = append(warns, proxyWarns...)
+		if err != nil {
+			return warns, errors.Wrap(err, "check proxysql capacity")
+		}
+		opts = append(opts, fmt.Sprintf("spec.proxysql.size=%d", s.Proxies))
+	}
+
+	err = p.UpdateDBCluster(name, strings.Join(opts, ","), "")
+	if err != nil {
+		return warns, errors.Wrap(err, "update cluster")
+	}
+
+	return warns, nil
+}
+
+// ClusterMembers returns wsrep_cluster_size of the cluster, the replset is ignored
+func (p *PXC) ClusterMembers(name, replset string) (int32, error) {
+	out, err := p.cmd.Exec(name+"-pxc-0", "pxc", "sh", "-c", `MYSQL_PWD="$MYSQL_ROOT_PASSWORD" mysql -uroot -Nse "SHOW GLOBAL STATUS LIKE 'wsrep_cluster_size'"`)
+	if err != nil {
+		return 0, errors.Wrap(err, "get wsrep_cluster_size")
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "wsrep_cluster_size" {
+			size, err := strconv.ParseInt(fields[1], 10, 32)
+			if err != nil {
+				return 0, errors.Wrapf(err, "parse wsrep_cluster_size %s", fields[1])
+			}
+			return int32(size), nil
+		}
+	}
+
+	return 0, errors.Errorf("no wsrep_cluster_size in the output: %s", out)
+}
@@ -0,0 +1,39 @@
+package pxc
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// SetupSecurity creates the TLS secrets of the PXC and ProxySQL services and checks that the Vault
+// secret of the data at rest encryption exists. PXC keeps the encryption keys in Vault only
+func (p *PXC) SetupSecurity(name, opts, version string, tls dbaas.TLS, encryption dbaas.Encryption) error {
+	if encryption.Enabled {
+		if len(encryption.KeySecret) == 0 {
+			return errors.New("data at rest encryption requires the secret with the Vault keyring configuration")
+		}
+		ext, err := p.cmd.IsObjExists("secret", encryption.KeySecret)
+		if err != nil {
+			return errors.Wrap(err, "check if Vault secret exists")
+		}
+		if !ext {
+			return errors.Errorf("Vault secret %s isn't found", encryption.KeySecret)
+		}
+	}
+	if len(tls.Mode) == 0 {
+		return nil
+	}
+
+	err := p.cmd.SetupTLS(name, tls, []string{name + "-pxc", name + "-proxysql"})
+	if err != nil {
+		return errors.Wrap(err, "setup TLS")
+	}
+
+	return nil
+}
+
+// DeleteSecurity deletes the TLS secrets and certificates created by SetupSecurity
+func (p *PXC) DeleteSecurity(name string, tls dbaas.TLS) error {
+	return p.cmd.DeleteTLS(name, tls)
+}
@@ -0,0 +1,42 @@
+package pxc
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/k8s"
+)
+
+// ResizeStorage expands the PXC volumes to the given size and sets the size in the cluster CR.
+// If the volumes can't be expanded in place only the CR is updated and the steps of the volumes replacement are returned
+func (p *PXC) ResizeStorage(name, size string) (dbaas.StorageResize, error) {
+	selector := k8s.ComponentSelector(name, "pxc")
+	res := dbaas.StorageResize{Expanded: true}
+	err := p.cmd.ExpandVolumes(selector, size)
+	if notSupported, ok := errors.Cause(err).(k8s.ErrExpansionNotSupported); ok {
+		res.Expanded = false
+		res.Reason = notSupported.Error()
+		res.Steps, err = p.cmd.VolumeReplacementSteps(selector)
+		if err != nil {
+			return res, errors.Wrap(err, "get replacement steps")
+		}
+	} else if err != nil {
+		return res, errors.Wrap(err, "expand volumes")
+	}
+
+	err = p.UpdateDBCluster(name, "spec.pxc.volumeSpec.persistentVolumeClaim.resources.requests[storage]="+size, "")
+	if err != nil {
+		return res, errors.Wrap(err, "update cluster")
+	}
+	err = p.cmd.RecreateStatefulSets(selector)
+	if err != nil {
+		return res, errors.Wrap(err, "recreate statefulsets")
+	}
+
+	return res, nil
+}
+
+// VolumesResizeState returns resize state of the PXC volumes
+func (p *PXC) VolumesResizeState(name string) ([]dbaas.VolumeResize, error) {
+	return p.cmd.VolumesResizeState(k8s.ComponentSelector(name, "pxc"))
+}
@@ -97,6 +97,57 @@ func (cr *PerconaXtraDBCluster) GetStatusHost() string {
 	return cr.Status.Host
 }
 
+// GetDBInfo returns cluster topology, storage, images and resources
+func (cr *PerconaXtraDBCluster) GetDBInfo() dbaas.DB {
+	db := dbaas.DB{
+		ResourceName: cr.ObjectMeta.Name,
+		Namespace:    cr.ObjectMeta.Namespace,
+		Status:       dbaas.State(cr.Status.Status),
+		Images:       make(map[string]string),
+		Created:      cr.ObjectMeta.CreationTimestamp.Time,
+		Labels:       cr.ObjectMeta.Labels,
+	}
+	if cr.Spec.PXC != nil {
+		db.Nodes = dbaas.Nodes{
+			Ready:   cr.Status.PXC.Ready,
+			Desired: cr.Spec.PXC.Size,
+		}
+		db.Images["pxc"] = cr.Spec.PXC.Image
+		if cr.Spec.PXC.Resources != nil && cr.Spec.PXC.Resources.Requests != nil {
+			db.CPU = cr.Spec.PXC.Resources.Requests.CPU
+			db.Memory = cr.Spec.PXC.Resources.Requests.Memory
+		}
+		if cr.Spec.PXC.VolumeSpec != nil && cr.Spec.PXC.VolumeSpec.PersistentVolumeClaim != nil {
+			pvc := cr.Spec.PXC.VolumeSpec.PersistentVolumeClaim
+			if size, ok := pvc.Resources.Requests[corev1.ResourceStorage]; ok {
+				db.Size = size.String()
+			}
+			if pvc.StorageClassName != nil {
+				db.StorageClass = *pvc.StorageClassName
+			}
+		}
+	}
+	if cr.Spec.ProxySQL != nil && cr.Spec.ProxySQL.Enabled {
+		db.Proxy = "proxysql"
+		db.ProxyNodes = dbaas.Nodes{
+			Ready:   cr.Status.ProxySQL.Ready,
+			Desired: cr.Spec.ProxySQL.Size,
+		}
+		db.Images["proxysql"] = cr.Spec.ProxySQL.Image
+	}
+	if cr.Spec.Backup != nil && len(cr.Spec.Backup.Image) > 0 {
+		db.Images["backup"] = cr.Spec.Backup.Image
+	}
+	if cr.Spec.PMM != nil {
+		db.PMMEnabled = cr.Spec.PMM.Enabled
+		if cr.Spec.PMM.Enabled {
+			db.Images["pmm"] = cr.Spec.PMM.Image
+		}
+	}
+
+	return db
+}
+
 func (cr *PerconaXtraDBCluster) SetDefaults() error {
 	one := intstr.FromInt(1)
 
@@ -0,0 +1,105 @@
+package pxc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+// OrphanedVolumes returns the volumes of the deleted PXC clusters
+func (p *PXC) OrphanedVolumes() ([]dbaas.Volume, error) {
+	return p.cmd.OrphanedVolumes("pxc", p.operatorName())
+}
+
+// AttachVolumes creates the cluster on top of its preserved volumes with the same number of PXC nodes and storage size.
+// The preserved secrets of the cluster are used so the operator can access the data with the same users
+func (p *PXC) AttachVolumes(name, opts, version string) error {
+	volumes, err := p.clusterVolumes(name)
+	if err != nil {
+		return err
+	}
+	ext, err := p.cmd.IsObjExists("secret", name+"-secrets")
+	if err != nil {
+		return errors.Wrap(err, "check if secrets exists")
+	}
+	if !ext {
+		return errors.Errorf("secret %s-secrets isn't found, the operator can't access the preserved data without the users passwords", name)
+	}
+
+	nodes := 0
+	var attachOpts []string
+	for _, v := range volumes {
+		if v.Component != "pxc" {
+			continue
+		}
+		if nodes == 0 && len(v.Size) > 0 {
+			attachOpts = append(attachOpts, "spec.pxc.volumeSpec.persistentVolumeClaim.resources.requests[storage]="+v.Size)
+		}
+		if nodes == 0 && len(v.StorageClass) > 0 {
+			attachOpts = append(attachOpts, "spec.pxc.volumeSpec.persistentVolumeClaim.storageClassName="+v.StorageClass)
+		}
+		nodes++
+	}
+	if nodes == 0 {
+		return errors.Errorf("no preserved pxc volumes of cluster %s", name)
+	}
+	attachOpts = append(attachOpts, fmt.Sprintf("spec.pxc.size=%d", nodes))
+	if len(opts) > 0 {
+		attachOpts = append(attachOpts, opts)
+	}
+
+	return p.CreateDBCluster(name, strings.Join(attachOpts, ","), "", version)
+}
+
+// PurgeVolumes deletes the preserved volumes and secrets of the deleted cluster
+func (p *PXC) PurgeVolumes(name string) error {
+	ext, err := p.cmd.IsObjExists("pxc", name)
+	if err != nil {
+		return errors.Wrap(err, "check if cluster exists")
+	}
+	if ext {
+		return errors.Errorf("cluster %s exists, use delete-db to delete it with its data", name)
+	}
+	_, err = p.clusterVolumes(name)
+	if err != nil {
+		return err
+	}
+
+	err = p.cmd.DeleteVolumeClaims(p.operatorName(), name)
+	if err != nil {
+		return errors.Wrap(err, "delete volumes")
+	}
+	ext, err = p.cmd.IsObjExists("secret", name+"-secrets")
+	if err != nil {
+		return errors.Wrap(err, "check if secrets exists")
+	}
+	if ext {
+		err = p.cmd.DeleteObject("secret", name+"-secrets")
+		if err != nil {
+			return errors.Wrap(err, "delete secret")
+		}
+	}
+
+	return nil
+}
+
+func (p *PXC) clusterVolumes(name string) ([]dbaas.Volume, error) {
+	volumes, err := p.OrphanedVolumes()
+	if err != nil {
+		return nil, errors.Wrap(err, "get preserved volumes")
+	}
+	var clusterVolumes []dbaas.Volume
+	for _, v := range volumes {
+		if v.Cluster == name {
+			clusterVolumes = append(clusterVolumes, v)
+		}
+	}
+	if len(clusterVolumes) == 0 {
+		return nil, errors.Errorf("no preserved volumes of cluster %s", name)
+	}
+
+	return clusterVolumes, nil
+}
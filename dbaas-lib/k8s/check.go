@@ -76,6 +76,16 @@ func (p *Cmd) PreCheck(name, version, operatorName, operatorImage, objectName st
 	return warnings, nil
 }
 
+// GetOperatorVersion returns the version of the deployed operator with the given name
+func (p *Cmd) GetOperatorVersion(operatorName string) (string, error) {
+	image, err := p.GetObjectsElement("deployment", operatorName, ".spec.template.spec.containers[0].image")
+	if err != nil {
+		return "", err
+	}
+
+	return getOperatorImageVersion(string(image))
+}
+
 func getOperatorImageVersion(image string) (string, error) {
 	imageArr := strings.Split(image, ":")
 	if len(imageArr) < 2 {
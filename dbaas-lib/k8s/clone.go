@@ -0,0 +1,298 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const stateCheckInterval = 5 * time.Second
+
+// BackupObjects describes the backup and restore objects of the operator
+type BackupObjects struct {
+	APIVersion  string
+	BackupKind  string
+	RestoreKind string
+	// BackupResource is the resource name of the backups used with kubectl get
+	BackupResource string
+	// RestoreResource is the resource name of the restores used with kubectl get
+	RestoreResource string
+	// BackupClusterField is the backup spec field with the cluster name
+	BackupClusterField string
+	// RestoreClusterField is the restore spec field with the cluster name
+	RestoreClusterField string
+	// BackupSucceeded is the state of the finished backup
+	BackupSucceeded string
+	// Failed are the states of the failed backup or restore
+	Failed []string
+	// RestoreSucceeded is the state of the finished restore
+	RestoreSucceeded string
+}
+
+type object struct {
+	APIVersion string                 `json:"apiVersion"`
+	Kind       string                 `json:"kind"`
+	Metadata   map[string]interface{} `json:"metadata"`
+	Spec       map[string]interface{} `json:"spec"`
+	Status     map[string]interface{} `json:"status,omitempty"`
+}
+
+type objectList struct {
+	Items []object `json:"items"`
+}
+
+// BackupStorages returns names of the backup storages of the cluster with the given type and name
+func (p Cmd) BackupStorages(typ, name string) ([]string, error) {
+	data, err := p.GetObject(typ, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "get cluster")
+	}
+	var cluster object
+	err = json.Unmarshal(data, &cluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal cluster")
+	}
+	backup, _ := cluster.Spec["backup"].(map[string]interface{})
+	if enabled, ok := backup["enabled"].(bool); ok && !enabled {
+		return nil, errors.Errorf("backups are disabled for cluster %s", name)
+	}
+	storages, _ := backup["storages"].(map[string]interface{})
+	var names []string
+	for n := range storages {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// CreateBackup creates backup of the cluster to the given storage and returns the backup name
+func (p Cmd) CreateBackup(o BackupObjects, clusterName, storage string) (string, error) {
+	name := clusterName + "-" + time.Now().Format("20060102150405") + "-" + GenRandString(5)
+	bcp := object{
+		APIVersion: o.APIVersion,
+		Kind:       o.BackupKind,
+		Metadata:   map[string]interface{}{"name": name},
+		Spec: map[string]interface{}{
+			o.BackupClusterField: clusterName,
+			"storageName":        storage,
+		},
+	}
+	data, err := json.Marshal(bcp)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal backup")
+	}
+	err = p.apply(string(data))
+	if err != nil {
+		return "", errors.Wrap(err, "apply backup")
+	}
+
+	return name, nil
+}
+
+// TakeBackup creates backup of the cluster with the given type and name and waits until it succeeds.
+// The storage may be empty if the cluster has only one backup storage
+func (p Cmd) TakeBackup(o BackupObjects, typ, clusterName, storage string, timeout time.Duration) (string, error) {
+	if len(storage) == 0 {
+		storages, err := p.BackupStorages(typ, clusterName)
+		if err != nil {
+			return "", errors.Wrap(err, "get backup storages")
+		}
+		switch len(storages) {
+		case 0:
+			return "", errors.Errorf("cluster %s has no backup storages", clusterName)
+		case 1:
+			storage = storages[0]
+		default:
+			return "", errors.Errorf("cluster %s has several backup storages, choose one of: %s", clusterName, strings.Join(storages, ", "))
+		}
+	}
+
+	name, err := p.CreateBackup(o, clusterName, storage)
+	if err != nil {
+		return "", err
+	}
+	err = p.WaitState(o.BackupResource, name, o.BackupSucceeded, o.Failed, timeout)
+	if err != nil {
+		return "", errors.Wrap(err, "wait backup")
+	}
+
+	return name, nil
+}
+
+// LatestBackup returns name of the latest succeeded backup of the cluster
+func (p Cmd) LatestBackup(o BackupObjects, clusterName string) (string, error) {
+	backups, err := p.succeededBackups(o, clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	latest, latestCompleted := "", ""
+	for _, b := range backups {
+		// completed time is in RFC 3339 format so it can be compared as a string
+		completed, _ := b.Status["completed"].(string)
+		if len(latest) == 0 || completed > latestCompleted {
+			latest, _ = b.Metadata["name"].(string)
+			latestCompleted = completed
+		}
+	}
+	if len(latest) == 0 {
+		return "", errors.Errorf("no succeeded backups of cluster %s", clusterName)
+	}
+
+	return latest, nil
+}
+
+// HasBackups returns true if the cluster has succeeded backups
+func (p Cmd) HasBackups(o BackupObjects, clusterName string) (bool, error) {
+	backups, err := p.succeededBackups(o, clusterName)
+
+	return len(backups) > 0, err
+}
+
+func (p Cmd) succeededBackups(o BackupObjects, clusterName string) ([]object, error) {
+	data, err := p.GetObjects(o.BackupResource)
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "get backups")
+	}
+	var list objectList
+	err = json.Unmarshal(data, &list)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal backups")
+	}
+
+	var backups []object
+	for _, b := range list.Items {
+		cluster, _ := b.Spec[o.BackupClusterField].(string)
+		state, _ := b.Status["state"].(string)
+		if cluster == clusterName && state == o.BackupSucceeded {
+			backups = append(backups, b)
+		}
+	}
+
+	return backups, nil
+}
+
+// BackupLocation returns the storage name and the destination of the backup with the given name
+func (p Cmd) BackupLocation(o BackupObjects, name string) (storage, destination string, err error) {
+	data, err := p.GetObject(o.BackupResource, name)
+	if err != nil {
+		return "", "", errors.Wrap(err, "get backup")
+	}
+	var b object
+	err = json.Unmarshal(data, &b)
+	if err != nil {
+		return "", "", errors.Wrap(err, "unmarshal backup")
+	}
+	storage, _ = b.Spec["storageName"].(string)
+	destination, _ = b.Status["destination"].(string)
+	// some operators keep the bucket of S3 backups apart from the destination
+	if s3, ok := b.Status["s3"].(map[string]interface{}); ok && !strings.Contains(destination, "://") {
+		bucket, _ := s3["bucket"].(string)
+		prefix, _ := s3["prefix"].(string)
+		destination = "s3://" + path.Join(bucket, prefix, destination)
+	}
+
+	return storage, destination, nil
+}
+
+// CreateRestore creates restore of the backup to the cluster and returns the restore name
+func (p Cmd) CreateRestore(o BackupObjects, clusterName, backupName string) (string, error) {
+	name := clusterName + "-" + time.Now().Format("20060102150405") + "-" + GenRandString(5)
+	restore := object{
+		APIVersion: o.APIVersion,
+		Kind:       o.RestoreKind,
+		Metadata:   map[string]interface{}{"name": name},
+		Spec: map[string]interface{}{
+			o.RestoreClusterField: clusterName,
+			"backupName":          backupName,
+		},
+	}
+	data, err := json.Marshal(restore)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal restore")
+	}
+	err = p.apply(string(data))
+	if err != nil {
+		return "", errors.Wrap(err, "apply restore")
+	}
+
+	return name, nil
+}
+
+// WaitState waits until the object state is the succeeded one. An error is returned
+// if the state is one of the failed states or the state isn't reached within the timeout
+func (p Cmd) WaitState(typ, name, succeeded string, failed []string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	state := ""
+	for time.Now().Before(deadline) {
+		data, err := p.GetObjectsElement(typ, name, ".status.state")
+		if err != nil && err != ErrNotFound {
+			return errors.Wrapf(err, "get %s state", name)
+		}
+		state = strings.TrimSpace(string(data))
+		if state == succeeded {
+			return nil
+		}
+		for _, f := range failed {
+			if state == f {
+				return errors.Errorf("%s %s state is %s", typ, name, state)
+			}
+		}
+		time.Sleep(stateCheckInterval)
+	}
+
+	return errors.Errorf("%s %s isn't %s after %s, the last state is '%s'", typ, name, succeeded, timeout, state)
+}
+
+// CloneCR returns the object of the cluster with the given type and name which has the source
+// cluster spec and the new name. The spec is changed with setSpec before marshaling
+func (p Cmd) CloneCR(typ, source, target string, setSpec func(spec map[string]interface{})) (string, error) {
+	data, err := p.GetObject(typ, source)
+	if err != nil {
+		return "", errors.Wrap(err, "get source cluster")
+	}
+	var src object
+	err = json.Unmarshal(data, &src)
+	if err != nil {
+		return "", errors.Wrap(err, "unmarshal source cluster")
+	}
+
+	cr := object{
+		APIVersion: src.APIVersion,
+		Kind:       src.Kind,
+		Metadata:   map[string]interface{}{"name": target},
+		Spec:       src.Spec,
+	}
+	if labels, ok := src.Metadata["labels"]; ok {
+		cr.Metadata["labels"] = labels
+	}
+	setSpec(cr.Spec)
+	data, err = json.Marshal(cr)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal cluster")
+	}
+
+	return string(data), nil
+}
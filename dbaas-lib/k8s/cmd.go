@@ -17,7 +17,6 @@ package k8s
 import (
 	"bytes"
 	"fmt"
-	"io/ioutil"
 	"math/rand"
 	"os"
 	"os/exec"
@@ -33,8 +32,9 @@ func init() {
 }
 
 var (
-	ErrOutOfMemory = errors.New("out of memory")
-	ErrNotFound    = errors.New("not found")
+	ErrOutOfMemory   = errors.New("out of memory")
+	ErrNotFound      = errors.New("not found")
+	ErrNoExecCommand = errors.New("unable to find neither '" + k8sExecDefault + "' nor '" + k8sExecCustom + "' exec files")
 )
 
 type PlatformType string
@@ -48,6 +48,7 @@ const (
 
 type Cmd struct {
 	environment string
+	kubeContext string
 	Namespace   string
 	execCommand string
 }
@@ -70,44 +71,53 @@ func (e ErrCmdRun) Error() string {
 	return fmt.Sprintf("failed to run `%s %s`, output: %s", e.cmd, strings.Join(e.args, " "), e.output)
 }
 
-func New(environment string) (*Cmd, error) {
+// New returns Cmd working with the kubeconfig of the given environment and the given kubeconfig context.
+// The current environment is used if the environment is empty, see UseEnv
+func New(environment, kubeContext string) (*Cmd, error) {
 	execCommand := k8sExecDefault
 	if _, err := exec.LookPath(execCommand); err != nil {
 		execCommand = k8sExecCustom
 		if _, err := exec.LookPath(execCommand); err != nil {
-			return nil, fmt.Errorf("unable to find neither '%s' nor '%s' exec files", k8sExecDefault, k8sExecCustom)
+			// ErrNoExecCommand is returned by the commands so Cmd can be created without kubectl
+			execCommand = ""
 		}
 	}
 
-	targetKubeConfig := os.Getenv("HOME") + "/.percona/" + environment + "/kubeconfig"
-	if len(environment) > 0 {
-		if _, err := exec.LookPath(targetKubeConfig); err != nil {
-			return &Cmd{
-				environment: targetKubeConfig,
-			}, nil
-
+	if len(environment) == 0 {
+		current, err := CurrentEnv()
+		if err != nil {
+			return nil, errors.Wrap(err, "get current env")
 		}
-		files, err := ioutil.ReadDir(os.Getenv("HOME") + "/.percona/")
+		environment = current
+	}
+
+	var kubeconfig string
+	if len(environment) > 0 {
+		err := validEnvName(environment)
 		if err != nil {
-			return nil, fmt.Errorf("can't read the content of ~/.percona: %v", err)
+			return nil, err
 		}
-		var dirs []string
-		for _, file := range files {
-			if _, err := exec.LookPath(os.Getenv("HOME") + "/.percona/" + file.Name() + "/kubeconfig"); err != nil && file.IsDir() {
-				dirs = append(dirs, file.Name())
+		kubeconfig = envKubeconfig(environment)
+		if _, err := os.Stat(kubeconfig); err != nil {
+			envs, lerr := ListEnvs()
+			if lerr != nil {
+				return nil, errors.Wrap(lerr, "list envs")
 			}
+			return nil, fmt.Errorf("can't find the requested env %s. Please use one of the following: %v", environment, envs)
 		}
-
-		return nil, fmt.Errorf("can't find the requested env. Please use one of ther following: %v", dirs)
-
 	}
+
 	return &Cmd{
-		environment: environment,
+		environment: kubeconfig,
+		kubeContext: kubeContext,
 		execCommand: execCommand,
 	}, nil
 }
 
 func (p Cmd) runCmd(cmd string, args ...string) ([]byte, error) {
+	if len(cmd) == 0 {
+		return nil, ErrNoExecCommand
+	}
 	o, err := p.runNTimes(3, cmd, args...)
 	if err != nil {
 
@@ -119,12 +129,7 @@ func (p Cmd) runCmd(cmd string, args ...string) ([]byte, error) {
 
 func (p Cmd) runNTimes(n int, cmd string, args ...string) (o []byte, err error) {
 	for i := 1; i <= n; i++ {
-		cli := exec.Command(cmd, args...)
-		cli.Env = os.Environ()
-		if len(p.environment) > 0 {
-			cli.Env = append(cli.Env, "KUBECONFIG="+p.environment)
-		}
-		o, err = cli.CombinedOutput()
+		o, err = p.command(cmd, args...).CombinedOutput()
 		if err != nil {
 			if strings.Contains(string(o), "Unable to connect to the server") && i < n {
 				continue
@@ -136,8 +141,21 @@ func (p Cmd) runNTimes(n int, cmd string, args ...string) (o []byte, err error)
 	return o, err
 }
 
+func (p Cmd) command(cmd string, args ...string) *exec.Cmd {
+	if len(p.kubeContext) > 0 {
+		args = append([]string{"--context", p.kubeContext}, args...)
+	}
+	cli := exec.Command(cmd, args...)
+	cli.Env = os.Environ()
+	if len(p.environment) > 0 {
+		cli.Env = append(cli.Env, "KUBECONFIG="+p.environment)
+	}
+
+	return cli
+}
+
 func (p Cmd) readOperatorLogs(operatorName string) ([]byte, error) {
-	return p.runCmd(p.execCommand, "logs", "-l", "name="+operatorName)
+	return p.runCmd(p.execCommand, p.withNamespace("logs", "-l", OperatorSelector(operatorName))...)
 }
 
 func (p Cmd) GetObjectsElement(typ, name, jsonPath string) ([]byte, error) {
@@ -164,7 +182,9 @@ func (p Cmd) GetObject(typ, name string) (objData []byte, err error) {
 	} else {
 		objData, err = p.runCmd(p.execCommand, "get", typ+"/"+name, "-o", "json")
 	}
-	if err != nil && strings.Contains(err.Error(), "Not found") {
+	if err != nil && (strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "Not found")) {
+		err = ErrNotFound
+	} else if err != nil && strings.Contains(err.Error(), "doesn't have a resource") {
 		err = ErrNotFound
 	}
 
@@ -172,8 +192,19 @@ func (p Cmd) GetObject(typ, name string) (objData []byte, err error) {
 }
 
 func (p Cmd) GetObjects(typ string) ([]byte, error) {
+	return p.getObjects(typ, false)
+}
+
+// GetObjectsInAllNamespaces returns objects of the given type from all namespaces
+func (p Cmd) GetObjectsInAllNamespaces(typ string) ([]byte, error) {
+	return p.getObjects(typ, true)
+}
+
+func (p Cmd) getObjects(typ string, allNamespaces bool) ([]byte, error) {
 	args := []string{"get", typ, "-o", "json"}
-	if len(p.Namespace) > 0 {
+	if allNamespaces {
+		args = append(args, "--all-namespaces")
+	} else if len(p.Namespace) > 0 {
 		args = append(args, []string{"-n", p.Namespace}...)
 	}
 	data, err := p.runCmd(p.execCommand, args...)
@@ -232,7 +263,12 @@ func (p Cmd) apply(k8sObj string) error {
 	return nil
 }
 
+// GetCurrentNamespace returns the namespace the commands run in: the namespace set in Cmd
+// or the namespace of the current kubeconfig context
 func (p Cmd) GetCurrentNamespace() (string, error) {
+	if len(p.Namespace) > 0 {
+		return p.Namespace, nil
+	}
 	o, err := p.runCmd(p.execCommand, "config", "view", "--minify", "--output", "jsonpath={..namespace}")
 	if err != nil {
 		return "", err
@@ -241,7 +277,7 @@ func (p Cmd) GetCurrentNamespace() (string, error) {
 }
 
 func (p Cmd) Annotate(resource, clusterName, annotName, instance string) error {
-	_, err := p.runCmd(p.execCommand, "annotate", resource, clusterName, annotName+"="+instance, "--overwrite=true")
+	_, err := p.runCmd(p.execCommand, p.withNamespace("annotate", resource, clusterName, annotName+"="+instance, "--overwrite=true")...)
 
 	return err
 }
@@ -258,7 +294,7 @@ func (p Cmd) IsObjExists(typ, name string) (bool, error) {
 		typ = "perconaservermongodbbackup.psmdb.percona.com"
 	}
 
-	out, err := p.runCmd(p.execCommand, "get", typ, name, "-o", "name")
+	out, err := p.runCmd(p.execCommand, p.withNamespace("get", typ, name, "-o", "name")...)
 	if err != nil && !strings.Contains(err.Error(), "NotFound") {
 		return false, errors.Wrapf(err, "get cr: %s", out)
 	}
@@ -267,7 +303,7 @@ func (p Cmd) IsObjExists(typ, name string) (bool, error) {
 }
 
 func (p Cmd) Instances(typ string) ([]string, error) {
-	out, err := p.runCmd(p.execCommand, "get", typ, "-o", "name")
+	out, err := p.runCmd(p.execCommand, p.withNamespace("get", typ, "-o", "name")...)
 	if err != nil && !strings.Contains(err.Error(), "NotFound") {
 		return nil, errors.Wrapf(err, "get objects: %s", out)
 	}
@@ -276,7 +312,7 @@ func (p Cmd) Instances(typ string) ([]string, error) {
 }
 
 func (p Cmd) GetServiceBrokerInstances(typ string) ([]byte, error) {
-	out, err := p.runCmd(p.execCommand, "get", typ, "-o", "jsonpath='{.items..metadata.annotations.broker-instance}'")
+	out, err := p.runCmd(p.execCommand, p.withNamespace("get", typ, "-o", "jsonpath='{.items..metadata.annotations.broker-instance}'")...)
 	if err != nil && !strings.Contains(err.Error(), "NotFound") {
 		return nil, errors.Wrapf(err, "get objects: %s", out)
 	}
@@ -352,5 +388,5 @@ func GetStringFromMap(input map[string]string) string {
 }
 
 func (p Cmd) GetObjectByLables(typ, lables string) ([]byte, error) {
-	return p.runCmd(p.execCommand, "get", typ, "-l", lables, "-o", "json")
+	return p.runCmd(p.execCommand, p.withNamespace("get", typ, "-l", lables, "-o", "json")...)
 }
@@ -49,9 +49,9 @@ func (p Cmd) delete(typ, name string) error {
 }
 
 func (p Cmd) deletePVC(operatorName, appName string) error {
-	out, err := p.runCmd(p.execCommand, "delete", "pvc",
+	out, err := p.runCmd(p.execCommand, p.withNamespace("delete", "pvc",
 		"-l", "app.kubernetes.io/managed-by="+operatorName+",app.kubernetes.io/instance="+appName,
-	)
+	)...)
 	if err != nil {
 		return errors.Wrapf(err, "get cr: %s", out)
 	}
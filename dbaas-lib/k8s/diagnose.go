@@ -0,0 +1,399 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+const (
+	maxPodRestarts     = 3
+	maxEventFindings   = 10
+	maxOperatorErrors  = 5
+	componentLabel     = "app.kubernetes.io/component"
+	instanceLabel      = "app.kubernetes.io/instance"
+	managedByLabel     = "app.kubernetes.io/managed-by"
+	operatorComponent  = "operator"
+	kubernetesResource = "kubernetes"
+)
+
+type events struct {
+	Items []corev1.Event `json:"items"`
+}
+
+type nodes struct {
+	Items []corev1.Node `json:"items"`
+}
+
+type pvcs struct {
+	Items []corev1.PersistentVolumeClaim `json:"items"`
+}
+
+// Diagnose runs generic checks of the cluster pods, volumes, events, nodes and operator
+func (p Cmd) Diagnose(operatorName, clusterName string) ([]dbaas.Finding, error) {
+	var findings []dbaas.Finding
+
+	operatorFindings, err := p.diagnoseOperator(operatorName, clusterName)
+	if err != nil {
+		return nil, errors.Wrap(err, "check operator")
+	}
+	findings = append(findings, operatorFindings...)
+
+	podsData, err := p.GetObjectByLables("pods", instanceLabel+"="+clusterName)
+	if err != nil {
+		return nil, errors.Wrap(err, "get pods")
+	}
+	var pods Pods
+	err = json.Unmarshal(podsData, &pods)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal pods data")
+	}
+	if len(pods.Items) == 0 {
+		findings = append(findings, dbaas.Finding{
+			Severity:  dbaas.SeverityCritical,
+			Component: kubernetesResource,
+			Problem:   "no pods found for the cluster",
+			Hint:      "check that the cluster is not paused and the operator is running",
+		})
+	}
+	findings = append(findings, diagnosePods(pods.Items)...)
+
+	pvcData, err := p.GetObjectByLables("pvc", instanceLabel+"="+clusterName)
+	if err != nil {
+		return nil, errors.Wrap(err, "get pvc")
+	}
+	var claims pvcs
+	err = json.Unmarshal(pvcData, &claims)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal pvc data")
+	}
+	findings = append(findings, diagnosePVCs(claims.Items)...)
+
+	eventsFindings, err := p.diagnoseEvents(clusterName)
+	if err != nil {
+		return nil, errors.Wrap(err, "check events")
+	}
+	findings = append(findings, eventsFindings...)
+
+	// nodes are cluster scoped objects and may be not visible for the current user
+	findings = append(findings, p.diagnoseNodes(pods.Items)...)
+
+	return findings, nil
+}
+
+func diagnosePods(pods []corev1.Pod) []dbaas.Finding {
+	var findings []dbaas.Finding
+	for _, pod := range pods {
+		component := pod.Labels[componentLabel]
+		if pod.Status.Phase == corev1.PodPending {
+			findings = append(findings, diagnosePendingPod(pod, component)...)
+			continue
+		}
+
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Waiting != nil {
+				findings = append(findings, diagnoseWaitingContainer(pod.Name, component, cs))
+			}
+			if cs.LastTerminationState.Terminated != nil && cs.LastTerminationState.Terminated.Reason == "OOMKilled" {
+				findings = append(findings, dbaas.Finding{
+					Severity:  dbaas.SeverityWarning,
+					Component: component,
+					Problem:   fmt.Sprintf("container %s in pod %s was killed because it ran out of memory", cs.Name, pod.Name),
+					Hint:      "increase memory limits of the component or decrease memory usage settings of the database",
+				})
+			}
+			if cs.RestartCount > maxPodRestarts {
+				findings = append(findings, dbaas.Finding{
+					Severity:  dbaas.SeverityWarning,
+					Component: component,
+					Problem:   fmt.Sprintf("container %s in pod %s restarted %d times", cs.Name, pod.Name, cs.RestartCount),
+					Hint:      fmt.Sprintf("check previous logs with 'kubectl logs %s -c %s --previous'", pod.Name, cs.Name),
+				})
+			}
+		}
+
+		if pod.Status.Phase == corev1.PodRunning && !isPodReady(pod) {
+			findings = append(findings, dbaas.Finding{
+				Severity:  dbaas.SeverityWarning,
+				Component: component,
+				Problem:   fmt.Sprintf("pod %s is running but not ready", pod.Name),
+				Hint:      "the node may be still joining the cluster; if it lasts long check the pod logs",
+			})
+		}
+	}
+
+	return findings
+}
+
+func diagnosePendingPod(pod corev1.Pod, component string) []dbaas.Finding {
+	var findings []dbaas.Finding
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodScheduled || condition.Status != corev1.ConditionFalse {
+			continue
+		}
+		f := dbaas.Finding{
+			Severity:  dbaas.SeverityCritical,
+			Component: component,
+			Problem:   fmt.Sprintf("pod %s can't be scheduled: %s", pod.Name, condition.Message),
+			Hint:      "check scheduling constraints of the pod with 'kubectl describe pod " + pod.Name + "'",
+		}
+		switch {
+		case strings.Contains(condition.Message, "Insufficient memory"), strings.Contains(condition.Message, "Insufficient cpu"):
+			f.Hint = "the Kubernetes cluster is out of resources: add nodes or decrease resource requests of the component"
+		case strings.Contains(condition.Message, "PersistentVolumeClaim"), strings.Contains(condition.Message, "persistent volumes"):
+			f.Hint = "the pod volume can't be bound: check that the storage class exists and has a working provisioner"
+		case strings.Contains(condition.Message, "anti-affinity"), strings.Contains(condition.Message, "affinity"):
+			f.Hint = "there are not enough nodes to satisfy anti-affinity rules: add nodes or set antiAffinityTopologyKey to none"
+		}
+		findings = append(findings, f)
+	}
+	if len(findings) == 0 {
+		findings = append(findings, dbaas.Finding{
+			Severity:  dbaas.SeverityWarning,
+			Component: component,
+			Problem:   fmt.Sprintf("pod %s is pending", pod.Name),
+			Hint:      "the pod may be still starting; if it lasts long check it with 'kubectl describe pod " + pod.Name + "'",
+		})
+	}
+
+	return findings
+}
+
+func diagnoseWaitingContainer(podName, component string, cs corev1.ContainerStatus) dbaas.Finding {
+	f := dbaas.Finding{
+		Severity:  dbaas.SeverityWarning,
+		Component: component,
+		Problem:   fmt.Sprintf("container %s in pod %s is waiting: %s %s", cs.Name, podName, cs.State.Waiting.Reason, cs.State.Waiting.Message),
+	}
+	switch cs.State.Waiting.Reason {
+	case "CrashLoopBackOff":
+		f.Severity = dbaas.SeverityCritical
+		f.Hint = fmt.Sprintf("the container keeps crashing, check its logs with 'kubectl logs %s -c %s --previous'", podName, cs.Name)
+	case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+		f.Severity = dbaas.SeverityCritical
+		f.Hint = "check the image name and tag and that the registry is reachable from the nodes"
+	case "CreateContainerConfigError":
+		f.Severity = dbaas.SeverityCritical
+		f.Hint = "check that all secrets and config maps referenced by the cluster exist"
+	}
+
+	return f
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func diagnosePVCs(claims []corev1.PersistentVolumeClaim) []dbaas.Finding {
+	var findings []dbaas.Finding
+	for _, pvc := range claims {
+		switch pvc.Status.Phase {
+		case corev1.ClaimPending:
+			storageClass := "default"
+			if pvc.Spec.StorageClassName != nil {
+				storageClass = *pvc.Spec.StorageClassName
+			}
+			findings = append(findings, dbaas.Finding{
+				Severity:  dbaas.SeverityCritical,
+				Component: "storage",
+				Problem:   fmt.Sprintf("volume claim %s is not bound", pvc.Name),
+				Hint:      fmt.Sprintf("check that storage class '%s' exists and can provision volumes: 'kubectl describe pvc %s'", storageClass, pvc.Name),
+			})
+		case corev1.ClaimLost:
+			findings = append(findings, dbaas.Finding{
+				Severity:  dbaas.SeverityCritical,
+				Component: "storage",
+				Problem:   fmt.Sprintf("volume claim %s lost its persistent volume", pvc.Name),
+				Hint:      "the data of this node can't be recovered from the volume; delete the claim and the pod to resync the node from the cluster",
+			})
+		}
+	}
+
+	return findings
+}
+
+func (p Cmd) diagnoseEvents(clusterName string) ([]dbaas.Finding, error) {
+	args := []string{"get", "events", "--field-selector", "type=Warning", "-o", "json"}
+	if len(p.Namespace) > 0 {
+		args = append(args, "-n", p.Namespace)
+	}
+	data, err := p.runCmd(p.execCommand, args...)
+	if err != nil {
+		return nil, err
+	}
+	var list events
+	err = json.Unmarshal(data, &list)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal events")
+	}
+
+	var findings []dbaas.Finding
+	seen := make(map[string]bool)
+	// the newest events are at the end of the list
+	for i := len(list.Items) - 1; i >= 0 && len(findings) < maxEventFindings; i-- {
+		e := list.Items[i]
+		if !strings.HasPrefix(e.InvolvedObject.Name, clusterName+"-") && !strings.Contains(e.InvolvedObject.Name, "-"+clusterName+"-") {
+			continue
+		}
+		key := e.Reason + e.Message
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		findings = append(findings, dbaas.Finding{
+			Severity:  dbaas.SeverityInfo,
+			Component: kubernetesResource,
+			Problem:   fmt.Sprintf("%s/%s: %s: %s (%d times)", strings.ToLower(e.InvolvedObject.Kind), e.InvolvedObject.Name, e.Reason, e.Message, e.Count),
+		})
+	}
+
+	return findings, nil
+}
+
+func (p Cmd) diagnoseOperator(operatorName, clusterName string) ([]dbaas.Finding, error) {
+	replicas, err := p.GetObjectsElement("deployment", operatorName, ".status.readyReplicas")
+	if err == ErrNotFound {
+		return []dbaas.Finding{{
+			Severity:  dbaas.SeverityCritical,
+			Component: operatorComponent,
+			Problem:   fmt.Sprintf("operator deployment %s not found", operatorName),
+			Hint:      "nothing will manage the cluster without the operator, create the cluster again to install it",
+		}}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "get operator deployment")
+	}
+	if len(strings.TrimSpace(string(replicas))) == 0 || strings.TrimSpace(string(replicas)) == "0" {
+		return []dbaas.Finding{{
+			Severity:  dbaas.SeverityCritical,
+			Component: operatorComponent,
+			Problem:   "operator is not running",
+			Hint:      fmt.Sprintf("check the operator pod with 'kubectl describe pod -l name=%s'", operatorName),
+		}}, nil
+	}
+
+	logs, err := p.readOperatorLogs(operatorName)
+	if err != nil {
+		return nil, errors.Wrap(err, "read operator logs")
+	}
+	var findings []dbaas.Finding
+	seen := make(map[string]bool)
+	lines := strings.Split(string(logs), "\n")
+	for i := len(lines) - 1; i >= 0 && len(findings) < maxOperatorErrors; i-- {
+		line := strings.TrimSpace(lines[i])
+		if !strings.Contains(line, clusterName) || !strings.Contains(strings.ToLower(line), "error") {
+			continue
+		}
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		findings = append(findings, dbaas.Finding{
+			Severity:  dbaas.SeverityWarning,
+			Component: operatorComponent,
+			Problem:   "operator reported an error: " + line,
+		})
+	}
+
+	return findings, nil
+}
+
+func (p Cmd) diagnoseNodes(pods []corev1.Pod) []dbaas.Finding {
+	used := make(map[string]bool)
+	for _, pod := range pods {
+		if len(pod.Spec.NodeName) > 0 {
+			used[pod.Spec.NodeName] = true
+		}
+	}
+	if len(used) == 0 {
+		return nil
+	}
+
+	data, err := p.runCmd(p.execCommand, "get", "nodes", "-o", "json")
+	if err != nil {
+		return nil
+	}
+	var list nodes
+	err = json.Unmarshal(data, &list)
+	if err != nil {
+		return nil
+	}
+
+	var findings []dbaas.Finding
+	for _, node := range list.Items {
+		if !used[node.Name] {
+			continue
+		}
+		for _, condition := range node.Status.Conditions {
+			switch condition.Type {
+			case corev1.NodeReady:
+				if condition.Status != corev1.ConditionTrue {
+					findings = append(findings, dbaas.Finding{
+						Severity:  dbaas.SeverityCritical,
+						Component: kubernetesResource,
+						Problem:   fmt.Sprintf("node %s is not ready: %s", node.Name, condition.Message),
+						Hint:      "pods on this node are unavailable, fix or replace the node",
+					})
+				}
+			case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure:
+				if condition.Status == corev1.ConditionTrue {
+					findings = append(findings, dbaas.Finding{
+						Severity:  dbaas.SeverityWarning,
+						Component: kubernetesResource,
+						Problem:   fmt.Sprintf("node %s has %s", node.Name, condition.Type),
+						Hint:      "pods on this node may be evicted, free the node resources or move the workload",
+					})
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+// QuorumFindings checks if a majority of the given component members is ready
+func QuorumFindings(component string, nodes dbaas.Nodes) []dbaas.Finding {
+	if nodes.Desired == 0 || nodes.Ready >= nodes.Desired {
+		return nil
+	}
+	if nodes.Ready*2 <= nodes.Desired {
+		return []dbaas.Finding{{
+			Severity:  dbaas.SeverityCritical,
+			Component: component,
+			Problem:   fmt.Sprintf("only %s members are ready, the majority is lost", nodes),
+			Hint:      "the cluster can't accept writes without the majority, bring the failed members back first",
+		}}
+	}
+
+	return []dbaas.Finding{{
+		Severity:  dbaas.SeverityWarning,
+		Component: component,
+		Problem:   fmt.Sprintf("only %s members are ready", nodes),
+		Hint:      "the cluster works but can't tolerate more failures",
+	}}
+}
@@ -0,0 +1,156 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	kubeconfigFile = "kubeconfig"
+	currentEnvFile = "current-env"
+)
+
+// EnvsDir returns the directory where environments kubeconfigs are stored
+func EnvsDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".percona")
+}
+
+func envKubeconfig(name string) string {
+	return filepath.Join(EnvsDir(), name, kubeconfigFile)
+}
+
+// validEnvName checks that the env name is a single path element so the env dir is inside EnvsDir
+func validEnvName(name string) error {
+	if len(name) == 0 || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return errors.Errorf("invalid env name '%s'", name)
+	}
+
+	return nil
+}
+
+// AddEnv saves a copy of the given kubeconfig as the environment with the given name
+func AddEnv(name, kubeconfig string) error {
+	err := validEnvName(name)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(kubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "read kubeconfig")
+	}
+	err = os.MkdirAll(filepath.Join(EnvsDir(), name), 0700)
+	if err != nil {
+		return errors.Wrap(err, "create env dir")
+	}
+
+	return errors.Wrap(ioutil.WriteFile(envKubeconfig(name), data, 0600), "write kubeconfig")
+}
+
+// ListEnvs returns names of the saved environments
+func ListEnvs() ([]string, error) {
+	files, err := ioutil.ReadDir(EnvsDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "read envs dir")
+	}
+
+	var envs []string
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(envKubeconfig(file.Name())); err == nil {
+			envs = append(envs, file.Name())
+		}
+	}
+	sort.Strings(envs)
+
+	return envs, nil
+}
+
+// RemoveEnv removes the environment with the given name. If it is the current environment
+// the default kubeconfig becomes current
+func RemoveEnv(name string) error {
+	err := validEnvName(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(envKubeconfig(name)); err != nil {
+		return errors.Errorf("env %s not found", name)
+	}
+	current, err := CurrentEnv()
+	if err != nil {
+		return errors.Wrap(err, "get current env")
+	}
+	if current == name {
+		err = UseEnv("")
+		if err != nil {
+			return errors.Wrap(err, "reset current env")
+		}
+	}
+
+	return errors.Wrap(os.RemoveAll(filepath.Join(EnvsDir(), name)), "remove env dir")
+}
+
+// UseEnv makes the environment with the given name current. Empty name switches to the default kubeconfig
+func UseEnv(name string) error {
+	path := filepath.Join(EnvsDir(), currentEnvFile)
+	if len(name) == 0 {
+		err := os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "remove current env file")
+		}
+		return nil
+	}
+	err := validEnvName(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(envKubeconfig(name)); err != nil {
+		return errors.Errorf("env %s not found", name)
+	}
+
+	return errors.Wrap(ioutil.WriteFile(path, []byte(name+"\n"), 0600), "write current env file")
+}
+
+// CurrentEnv returns the name of the current environment, it is empty if the default kubeconfig is used
+func CurrentEnv() (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(EnvsDir(), currentEnvFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "read current env file")
+	}
+	name := strings.TrimSpace(string(data))
+	if len(name) == 0 {
+		return "", nil
+	}
+	err = validEnvName(name)
+	if err != nil {
+		return "", errors.Wrap(err, "current env file")
+	}
+
+	return name, nil
+}
@@ -0,0 +1,133 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// OperatorSelector returns label selector of the operator pods
+func OperatorSelector(operatorName string) string {
+	return "name=" + operatorName
+}
+
+// StreamLogs writes logs of all pods matching the label selector to w prefixing each line with the pod name.
+// If container is empty logs of all pod containers are written. Since limits logs to the given
+// relative duration like 5s, 2m or 3h. With follow the logs are streamed from all pods simultaneously
+// until kubectl exits.
+func (p Cmd) StreamLogs(selector, container string, follow bool, since string, w io.Writer) error {
+	podsData, err := p.GetObjectByLables("pods", selector)
+	if err != nil {
+		return errors.Wrap(err, "get pods")
+	}
+	var pods Pods
+	err = json.Unmarshal(podsData, &pods)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal pods data")
+	}
+	if len(pods.Items) == 0 {
+		return ErrNotFound
+	}
+
+	out := &lineWriter{w: w}
+	if !follow {
+		for _, pod := range pods.Items {
+			err = p.streamPodLogs(pod.Name, container, false, since, out)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	errs := make(chan error, len(pods.Items))
+	var wg sync.WaitGroup
+	for _, pod := range pods.Items {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			errs <- p.streamPodLogs(name, container, true, since, out)
+		}(pod.Name)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p Cmd) streamPodLogs(pod, container string, follow bool, since string, out *lineWriter) error {
+	if len(p.execCommand) == 0 {
+		return ErrNoExecCommand
+	}
+	args := []string{"logs", pod}
+	if len(container) > 0 {
+		args = append(args, "-c", container)
+	} else {
+		args = append(args, "--all-containers=true")
+	}
+	if follow {
+		args = append(args, "--follow")
+	}
+	if len(since) > 0 {
+		args = append(args, "--since="+since)
+	}
+
+	cli := p.command(p.execCommand, p.withNamespace(args...)...)
+	stdout, err := cli.StdoutPipe()
+	if err != nil {
+		return errors.Wrapf(err, "get %s logs output", pod)
+	}
+	cli.Stderr = cli.Stdout
+	err = cli.Start()
+	if err != nil {
+		return errors.Wrapf(err, "start reading %s logs", pod)
+	}
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		out.writeLine(pod, scanner.Text())
+	}
+	err = scanner.Err()
+	if err != nil {
+		cli.Wait()
+		return errors.Wrapf(err, "read %s logs", pod)
+	}
+
+	return errors.Wrapf(cli.Wait(), "read %s logs", pod)
+}
+
+// lineWriter writes whole lines from several goroutines without mixing them
+type lineWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lineWriter) writeLine(prefix, line string) {
+	l.mu.Lock()
+	fmt.Fprintf(l.w, "[%s] %s\n", prefix, line)
+	l.mu.Unlock()
+}
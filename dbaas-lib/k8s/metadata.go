@@ -0,0 +1,118 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/options"
+)
+
+// ExpiryAnnotation is the cluster object annotation with the time in RFC 3339 format the cluster expires at
+const ExpiryAnnotation = "dbaas.percona.com/expires-at"
+
+// ExpiryOption returns the option which sets the cluster expiry after the given TTL from now
+func ExpiryOption(ttl time.Duration) string {
+	return MetadataOptions(nil, map[string]string{ExpiryAnnotation: time.Now().Add(ttl).UTC().Format(time.RFC3339)})
+}
+
+// ExpiresAt returns the expiry time of the cluster object or nil if the cluster doesn't expire
+func ExpiresAt(cluster []byte) *time.Time {
+	var obj object
+	err := json.Unmarshal(cluster, &obj)
+	if err != nil {
+		return nil
+	}
+	annotations, _ := obj.Metadata["annotations"].(map[string]interface{})
+	value, _ := annotations[ExpiryAnnotation].(string)
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+// MetadataOptions returns the options which add the labels and annotations to the cluster object
+func MetadataOptions(labels, annotations map[string]string) string {
+	var opts []string
+	for _, k := range sortedKeys(labels) {
+		opts = append(opts, "metadata.labels["+k+"]="+options.Quote(labels[k]))
+	}
+	for _, k := range sortedKeys(annotations) {
+		opts = append(opts, "metadata.annotations["+k+"]="+options.Quote(annotations[k]))
+	}
+
+	return strings.Join(opts, ",")
+}
+
+// SetMetadata adds the labels and annotations to the object with the given type and name
+func (p Cmd) SetMetadata(typ, name string, labels, annotations map[string]string) error {
+	if len(labels) > 0 {
+		args := []string{"label", typ, name, "--overwrite=true"}
+		for _, k := range sortedKeys(labels) {
+			args = append(args, k+"="+labels[k])
+		}
+		out, err := p.runCmd(p.execCommand, p.withNamespace(args...)...)
+		if err != nil {
+			return errors.Wrapf(err, "label %s/%s: %s", typ, name, out)
+		}
+	}
+	if len(annotations) > 0 {
+		args := []string{"annotate", typ, name, "--overwrite=true"}
+		for _, k := range sortedKeys(annotations) {
+			args = append(args, k+"="+annotations[k])
+		}
+		out, err := p.runCmd(p.execCommand, p.withNamespace(args...)...)
+		if err != nil {
+			return errors.Wrapf(err, "annotate %s/%s: %s", typ, name, out)
+		}
+	}
+
+	return nil
+}
+
+// WaitObject waits until the object with the given type and name exists
+func (p Cmd) WaitObject(typ, name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		ext, err := p.IsObjExists(typ, name)
+		if err != nil {
+			return errors.Wrapf(err, "check if %s/%s exists", typ, name)
+		}
+		if ext {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("%s/%s doesn't exist after %s", typ, name, timeout)
+		}
+		time.Sleep(stateCheckInterval)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,33 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import "encoding/json"
+
+// PMMServer returns the PMM server host of the cluster object or an empty string if PMM isn't enabled
+func PMMServer(cluster []byte) string {
+	var obj object
+	err := json.Unmarshal(cluster, &obj)
+	if err != nil {
+		return ""
+	}
+	pmm, _ := obj.Spec["pmm"].(map[string]interface{})
+	if enabled, _ := pmm["enabled"].(bool); !enabled {
+		return ""
+	}
+	host, _ := pmm["serverHost"].(string)
+
+	return host
+}
@@ -0,0 +1,56 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// DeletionProtectionAnnotation is the cluster object annotation which protects the cluster from deletion if it is "true"
+const DeletionProtectionAnnotation = "dbaas.percona.com/deletion-protection"
+
+// SetDeletionProtection enables or disables the deletion protection of the cluster with the given type and name
+func (p Cmd) SetDeletionProtection(typ, name string, enabled bool) error {
+	err := p.Annotate(typ, name, DeletionProtectionAnnotation, strconv.FormatBool(enabled))
+	if err != nil {
+		return errors.Wrap(err, "annotate cluster")
+	}
+
+	return nil
+}
+
+// DeletionProtected returns true if the cluster with the given type and name is protected from deletion.
+// A cluster which doesn't exist isn't protected
+func (p Cmd) DeletionProtected(typ, name string) (bool, error) {
+	data, err := p.GetObject(typ, name)
+	if err == ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "get cluster")
+	}
+	var cluster object
+	err = json.Unmarshal(data, &cluster)
+	if err != nil {
+		return false, errors.Wrap(err, "unmarshal cluster")
+	}
+	annotations, _ := cluster.Metadata["annotations"].(map[string]interface{})
+	value, _ := annotations[DeletionProtectionAnnotation].(string)
+
+	return value == "true", nil
+}
@@ -0,0 +1,72 @@
+//go:build !windows
+// +build !windows
+
+package k8s_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib/k8s"
+)
+
+// fakeKubectl puts kubectl printing the given output and exiting with the given code first in PATH
+func fakeKubectl(t *testing.T, output string, code int) func() {
+	dir, err := ioutil.TempDir("", "kubectl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\necho '" + output + "'\nexit " + strconv.Itoa(code) + "\n"
+	err = ioutil.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, home := os.Getenv("PATH"), os.Getenv("HOME")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+	os.Setenv("HOME", dir)
+
+	return func() {
+		os.Setenv("PATH", path)
+		os.Setenv("HOME", home)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestDeletionProtectedNotFound(t *testing.T) {
+	defer fakeKubectl(t, `Error from server (NotFound): perconaxtradbclusters.pxc.percona.com "foo" not found`, 1)()
+
+	cmd, err := k8s.New("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = cmd.GetObject("pxc", "foo")
+	if err != k8s.ErrNotFound {
+		t.Errorf("got error %v, want %v", err, k8s.ErrNotFound)
+	}
+	protected, err := cmd.DeletionProtected("pxc", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if protected {
+		t.Error("missing cluster is protected")
+	}
+}
+
+func TestDeletionProtected(t *testing.T) {
+	defer fakeKubectl(t, `{"metadata": {"name": "foo", "annotations": {"`+k8s.DeletionProtectionAnnotation+`": "true"}}}`, 0)()
+
+	cmd, err := k8s.New("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	protected, err := cmd.DeletionProtected("pxc", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !protected {
+		t.Error("cluster isn't protected")
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const hostnameTopologyKey = "kubernetes.io/hostname"
+
+// CheckCapacity checks that allocatable resources of the nodes are enough for the given number of new pods
+// with the resource requests of the pods with the given labels. The returned warnings describe the checks
+// which couldn't be done, e.g. if nodes aren't visible for the current user
+func (p Cmd) CheckCapacity(selector string, newPods int) ([]string, error) {
+	if newPods <= 0 {
+		return nil, nil
+	}
+	data, err := p.runCmd(p.execCommand, p.withNamespace("get", "pods", "-l", selector, "-o", "json")...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get pods: %s", data)
+	}
+	var pods Pods
+	err = json.Unmarshal(data, &pods)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal pods")
+	}
+	if len(pods.Items) == 0 {
+		return []string{"no running pods to take resource requests from, node capacity isn't checked"}, nil
+	}
+	cpu, memory := podRequests(pods.Items[0])
+	if cpu.IsZero() && memory.IsZero() {
+		return nil, nil
+	}
+
+	// nodes and pods of other namespaces are cluster scoped and may be not visible for the current user
+	data, err = p.runCmd(p.execCommand, "get", "nodes", "-o", "json")
+	if err != nil {
+		return []string{"can't get nodes, node capacity isn't checked: " + err.Error()}, nil
+	}
+	var nodeList nodes
+	err = json.Unmarshal(data, &nodeList)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal nodes")
+	}
+	data, err = p.runCmd(p.execCommand, "get", "pods", "--all-namespaces", "--field-selector", "status.phase!=Succeeded,status.phase!=Failed", "-o", "json")
+	if err != nil {
+		return []string{"can't get pods of all namespaces, node capacity isn't checked: " + err.Error()}, nil
+	}
+	var allPods Pods
+	err = json.Unmarshal(data, &allPods)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal pods")
+	}
+
+	// pods with hostname anti-affinity can't share nodes with each other
+	onePerNode := hasHostnameAntiAffinity(pods.Items[0])
+	occupied := make(map[string]bool)
+	for _, pod := range pods.Items {
+		occupied[pod.Spec.NodeName] = true
+	}
+	usedCPU := make(map[string]*resource.Quantity)
+	usedMemory := make(map[string]*resource.Quantity)
+	for _, pod := range allPods.Items {
+		if len(pod.Spec.NodeName) == 0 {
+			continue
+		}
+		c, m := podRequests(pod)
+		if usedCPU[pod.Spec.NodeName] == nil {
+			usedCPU[pod.Spec.NodeName] = resource.NewQuantity(0, resource.DecimalSI)
+			usedMemory[pod.Spec.NodeName] = resource.NewQuantity(0, resource.BinarySI)
+		}
+		usedCPU[pod.Spec.NodeName].Add(c)
+		usedMemory[pod.Spec.NodeName].Add(m)
+	}
+
+	fit := 0
+	for _, node := range nodeList.Items {
+		if node.Spec.Unschedulable || !isNodeReady(node) || (onePerNode && occupied[node.Name]) {
+			continue
+		}
+		freeCPU := node.Status.Allocatable[corev1.ResourceCPU]
+		freeMemory := node.Status.Allocatable[corev1.ResourceMemory]
+		if usedCPU[node.Name] != nil {
+			freeCPU.Sub(*usedCPU[node.Name])
+			freeMemory.Sub(*usedMemory[node.Name])
+		}
+		n := podsFit(freeCPU.MilliValue(), cpu.MilliValue(), freeMemory.Value(), memory.Value())
+		if onePerNode && n > 1 {
+			n = 1
+		}
+		fit += n
+	}
+	if fit < newPods {
+		return nil, errors.Errorf("not enough resources on the Kubernetes nodes for %d new pods requesting cpu=%s, memory=%s each: only %d can be scheduled",
+			newPods, cpu.String(), memory.String(), fit)
+	}
+
+	return nil, nil
+}
+
+// Exec runs the command in the container of the pod and returns its output
+func (p Cmd) Exec(pod, container string, command ...string) ([]byte, error) {
+	args := p.withNamespace("exec", pod, "-c", container)
+	args = append(args, "--")
+	args = append(args, command...)
+
+	return p.runCmd(p.execCommand, args...)
+}
+
+func podRequests(pod corev1.Pod) (cpu, memory resource.Quantity) {
+	for _, c := range pod.Spec.Containers {
+		cpu.Add(c.Resources.Requests[corev1.ResourceCPU])
+		memory.Add(c.Resources.Requests[corev1.ResourceMemory])
+	}
+
+	return cpu, memory
+}
+
+func podsFit(freeCPU, cpu, freeMemory, memory int64) int {
+	if freeCPU < 0 || freeMemory < 0 {
+		return 0
+	}
+	n := int64(-1)
+	if cpu > 0 {
+		n = freeCPU / cpu
+	}
+	if memory > 0 && (n < 0 || freeMemory/memory < n) {
+		n = freeMemory / memory
+	}
+
+	return int(n)
+}
+
+func hasHostnameAntiAffinity(pod corev1.Pod) bool {
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.PodAntiAffinity == nil {
+		return false
+	}
+	for _, term := range pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		if term.TopologyKey == hostnameTopologyKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
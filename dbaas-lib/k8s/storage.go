@@ -0,0 +1,293 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+const (
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+	replsetLabel                  = "app.kubernetes.io/replset"
+)
+
+type storageClasses struct {
+	Items []storagev1.StorageClass `json:"items"`
+}
+
+// ErrExpansionNotSupported is returned if the storage class of the volumes doesn't allow volume expansion
+type ErrExpansionNotSupported struct {
+	StorageClass string
+}
+
+func (e ErrExpansionNotSupported) Error() string {
+	if len(e.StorageClass) == 0 {
+		return "default storage class doesn't allow volume expansion"
+	}
+	return fmt.Sprintf("storage class %s doesn't allow volume expansion", e.StorageClass)
+}
+
+// ComponentSelector returns label selector of the cluster component pods, volumes and StatefulSets
+func ComponentSelector(clusterName, component string) string {
+	return instanceLabel + "=" + clusterName + "," + componentLabel + "=" + component
+}
+
+// ExpandVolumes sets storage request of the volume claims with the given labels to the given size.
+// ErrExpansionNotSupported is returned and no claim is changed if any storage class of the claims doesn't allow volume expansion
+func (p Cmd) ExpandVolumes(selector, size string) error {
+	newSize, err := resource.ParseQuantity(size)
+	if err != nil {
+		return errors.Wrapf(err, "parse size %s", size)
+	}
+	claims, err := p.volumeClaims(selector)
+	if err != nil {
+		return err
+	}
+
+	expandable := make(map[string]bool)
+	for _, pvc := range claims {
+		if current, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok && newSize.Cmp(current) <= 0 {
+			return errors.Errorf("new size %s should be greater than the current size %s of volume %s", size, current.String(), pvc.Name)
+		}
+		class := ""
+		if pvc.Spec.StorageClassName != nil {
+			class = *pvc.Spec.StorageClassName
+		}
+		if _, ok := expandable[class]; !ok {
+			expandable[class], err = p.storageClassExpandable(class)
+			if err != nil {
+				return errors.Wrapf(err, "check storage class of volume %s", pvc.Name)
+			}
+		}
+		if !expandable[class] {
+			return ErrExpansionNotSupported{StorageClass: class}
+		}
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"resources":{"requests":{"storage":"%s"}}}}`, size)
+	for _, pvc := range claims {
+		out, err := p.runCmd(p.execCommand, p.withNamespace("patch", "pvc", pvc.Name, "-p", patch)...)
+		if err != nil {
+			return errors.Wrapf(err, "patch volume claim %s: %s", pvc.Name, out)
+		}
+	}
+
+	return nil
+}
+
+// VolumesResizeState returns resize state of the volume claims with the given labels
+func (p Cmd) VolumesResizeState(selector string) ([]dbaas.VolumeResize, error) {
+	claims, err := p.volumeClaims(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var volumes []dbaas.VolumeResize
+	for _, pvc := range claims {
+		requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		capacity := pvc.Status.Capacity[corev1.ResourceStorage]
+		v := dbaas.VolumeResize{
+			Name:      pvc.Name,
+			Capacity:  capacity.String(),
+			Requested: requested.String(),
+			State:     dbaas.VolumeResizing,
+		}
+		if capacity.Cmp(requested) >= 0 {
+			v.State = dbaas.VolumeResized
+		}
+		for _, c := range pvc.Status.Conditions {
+			if c.Type == corev1.PersistentVolumeClaimFileSystemResizePending && c.Status == corev1.ConditionTrue {
+				v.State = dbaas.VolumeFileSystemResizePending
+			}
+		}
+		volumes = append(volumes, v)
+	}
+
+	return volumes, nil
+}
+
+// RecreateStatefulSets deletes the StatefulSets with the given labels leaving their pods running,
+// so the operator creates them again with the volume claim templates of the updated cluster object
+func (p Cmd) RecreateStatefulSets(selector string) error {
+	out, err := p.runCmd(p.execCommand, p.withNamespace("delete", "statefulset", "-l", selector, "--cascade=false")...)
+	if err != nil {
+		return errors.Wrapf(err, "delete statefulsets: %s", out)
+	}
+
+	return nil
+}
+
+// VolumeReplacementSteps returns the manual steps to replace the volumes of the pods with the given labels one by one
+func (p Cmd) VolumeReplacementSteps(selector string) ([]string, error) {
+	data, err := p.runCmd(p.execCommand, p.withNamespace("get", "pods", "-l", selector, "-o", "json")...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get pods: %s", data)
+	}
+	var pods Pods
+	err = json.Unmarshal(data, &pods)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal pods")
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+
+	namespace := ""
+	if len(p.Namespace) > 0 {
+		namespace = " -n " + p.Namespace
+	}
+	var steps []string
+	for _, pod := range pods.Items {
+		for _, v := range pod.Spec.Volumes {
+			if v.PersistentVolumeClaim == nil {
+				continue
+			}
+			steps = append(steps, fmt.Sprintf("kubectl delete pvc %s%s --wait=false && kubectl delete pod %s%s, then wait until the pod is ready and synced with the cluster",
+				v.PersistentVolumeClaim.ClaimName, namespace, pod.Name, namespace))
+		}
+	}
+
+	return steps, nil
+}
+
+func (p Cmd) volumeClaims(selector string) ([]corev1.PersistentVolumeClaim, error) {
+	data, err := p.runCmd(p.execCommand, p.withNamespace("get", "pvc", "-l", selector, "-o", "json")...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get volume claims: %s", data)
+	}
+	var claims pvcs
+	err = json.Unmarshal(data, &claims)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal volume claims")
+	}
+	if len(claims.Items) == 0 {
+		return nil, errors.New("no volume claims found")
+	}
+	sort.Slice(claims.Items, func(i, j int) bool {
+		return claims.Items[i].Name < claims.Items[j].Name
+	})
+
+	return claims.Items, nil
+}
+
+func (p Cmd) storageClassExpandable(name string) (bool, error) {
+	var class storagev1.StorageClass
+	if len(name) > 0 {
+		data, err := p.runCmd(p.execCommand, "get", "storageclass", name, "-o", "json")
+		if err != nil {
+			return false, errors.Wrapf(err, "get storage class: %s", data)
+		}
+		err = json.Unmarshal(data, &class)
+		if err != nil {
+			return false, errors.Wrap(err, "unmarshal storage class")
+		}
+	} else {
+		data, err := p.runCmd(p.execCommand, "get", "storageclass", "-o", "json")
+		if err != nil {
+			return false, errors.Wrapf(err, "get storage classes: %s", data)
+		}
+		var classes storageClasses
+		err = json.Unmarshal(data, &classes)
+		if err != nil {
+			return false, errors.Wrap(err, "unmarshal storage classes")
+		}
+		for _, c := range classes.Items {
+			if c.Annotations[defaultStorageClassAnnotation] == "true" {
+				class = c
+			}
+		}
+	}
+
+	return class.AllowVolumeExpansion != nil && *class.AllowVolumeExpansion, nil
+}
+
+// OrphanedVolumes returns the volumes created by the operator for the clusters of the given type which don't exist anymore
+func (p Cmd) OrphanedVolumes(typ, operatorName string) ([]dbaas.Volume, error) {
+	data, err := p.runCmd(p.execCommand, p.withNamespace("get", "pvc", "-l", managedByLabel+"="+operatorName, "-o", "json")...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get volume claims: %s", data)
+	}
+	var claims pvcs
+	err = json.Unmarshal(data, &claims)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal volume claims")
+	}
+	if len(claims.Items) == 0 {
+		return nil, nil
+	}
+
+	clusters := make(map[string]bool)
+	data, err = p.GetObjects(typ)
+	if err != nil && err != ErrNotFound {
+		return nil, errors.Wrap(err, "get clusters")
+	}
+	if err == nil {
+		var list objectList
+		err = json.Unmarshal(data, &list)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshal clusters")
+		}
+		for _, c := range list.Items {
+			name, _ := c.Metadata["name"].(string)
+			clusters[name] = true
+		}
+	}
+
+	var volumes []dbaas.Volume
+	for _, pvc := range claims.Items {
+		if clusters[pvc.Labels[instanceLabel]] {
+			continue
+		}
+		v := dbaas.Volume{
+			Name:      pvc.Name,
+			Cluster:   pvc.Labels[instanceLabel],
+			Component: pvc.Labels[componentLabel],
+			Replset:   pvc.Labels[replsetLabel],
+			Created:   pvc.CreationTimestamp.Time,
+		}
+		if size, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			v.Size = size.String()
+		}
+		if pvc.Spec.StorageClassName != nil {
+			v.StorageClass = *pvc.Spec.StorageClassName
+		}
+		volumes = append(volumes, v)
+	}
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Name < volumes[j].Name
+	})
+
+	return volumes, nil
+}
+
+// DeleteVolumeClaims deletes the volume claims created by the operator for the cluster with the given name
+func (p Cmd) DeleteVolumeClaims(operatorName, clusterName string) error {
+	selector := managedByLabel + "=" + operatorName + "," + instanceLabel + "=" + clusterName
+	out, err := p.runCmd(p.execCommand, p.withNamespace("delete", "pvc", "-l", selector)...)
+	if err != nil {
+		return errors.Wrapf(err, "delete volume claims: %s", out)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	redacted       = "[REDACTED]"
+	minRedactedLen = 4
+)
+
+var passwordPattern = regexp.MustCompile(`(?i)((?:password|passwd|\bpwd)["']?\s*[:=]\s*["']?)[^\s"',}]+`)
+
+// SupportBundle collects the cluster object, operator and pods logs, events, volume claims and services
+// into a gzipped tar archive written to w. Values of the secrets with the given names are redacted.
+// Failures to collect separate parts don't stop the collection and are written to the archive instead.
+func (p Cmd) SupportBundle(typ, operatorName, clusterName string, secretNames []string, w io.Writer) error {
+	var secretValues []string
+	for _, name := range secretNames {
+		secrets, err := p.GetSecrets(name)
+		if err != nil {
+			continue
+		}
+		for k, v := range secrets {
+			// user names are kept to make logs readable
+			if strings.HasSuffix(strings.ToUpper(k), "USER") || len(v) < minRedactedLen {
+				continue
+			}
+			secretValues = append(secretValues, string(v))
+		}
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	dir := clusterName + "-" + time.Now().Format("20060102-150405") + "/"
+	add := func(name string, data []byte, err error) error {
+		if err != nil {
+			data = append(data, []byte("\nfailed to collect: "+err.Error()+"\n")...)
+		}
+		data = redact(data, secretValues)
+		hdr := &tar.Header{
+			Name:    dir + name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: time.Now(),
+		}
+		err = tw.WriteHeader(hdr)
+		if err != nil {
+			return errors.Wrapf(err, "write %s header", name)
+		}
+		_, err = tw.Write(data)
+
+		return errors.Wrapf(err, "write %s", name)
+	}
+
+	selector := instanceLabel + "=" + clusterName
+	data, err := p.getYAML(typ, clusterName)
+	if err = add("cluster.yaml", data, err); err != nil {
+		return err
+	}
+	data, err = p.readOperatorLogs(operatorName)
+	if err = add("operator.log", data, err); err != nil {
+		return err
+	}
+	data, err = p.getYAML("deployment", operatorName)
+	if err = add("operator-deployment.yaml", data, err); err != nil {
+		return err
+	}
+	data, err = p.getYAMLByLabels("pods", selector)
+	if err = add("pods.yaml", data, err); err != nil {
+		return err
+	}
+	data, err = p.getYAMLByLabels("pvc", selector)
+	if err = add("pvc.yaml", data, err); err != nil {
+		return err
+	}
+	data, err = p.getYAMLByLabels("svc", selector)
+	if err = add("services.yaml", data, err); err != nil {
+		return err
+	}
+	data, err = p.runCmd(p.execCommand, p.withNamespace("get", "events", "--sort-by=.lastTimestamp")...)
+	if err = add("events.txt", data, err); err != nil {
+		return err
+	}
+
+	podsData, err := p.GetObjectByLables("pods", selector)
+	if err != nil {
+		return errors.Wrap(err, "get pods")
+	}
+	var pods Pods
+	err = json.Unmarshal(podsData, &pods)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal pods data")
+	}
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			data, err = p.runCmd(p.execCommand, p.withNamespace("logs", pod.Name, "-c", cs.Name)...)
+			if err = add(fmt.Sprintf("logs/%s/%s.log", pod.Name, cs.Name), data, err); err != nil {
+				return err
+			}
+			if cs.RestartCount == 0 {
+				continue
+			}
+			data, err = p.runCmd(p.execCommand, p.withNamespace("logs", pod.Name, "-c", cs.Name, "--previous")...)
+			if err = add(fmt.Sprintf("logs/%s/%s.previous.log", pod.Name, cs.Name), data, err); err != nil {
+				return err
+			}
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return errors.Wrap(err, "close tar")
+	}
+
+	return errors.Wrap(gz.Close(), "close gzip")
+}
+
+func (p Cmd) getYAML(typ, name string) ([]byte, error) {
+	return p.runCmd(p.execCommand, p.withNamespace("get", typ, name, "-o", "yaml")...)
+}
+
+func (p Cmd) getYAMLByLabels(typ, labels string) ([]byte, error) {
+	return p.runCmd(p.execCommand, p.withNamespace("get", typ, "-l", labels, "-o", "yaml")...)
+}
+
+func (p Cmd) withNamespace(args ...string) []string {
+	if len(p.Namespace) > 0 {
+		args = append(args, "-n", p.Namespace)
+	}
+
+	return args
+}
+
+func redact(data []byte, secretValues []string) []byte {
+	for _, v := range secretValues {
+		data = bytes.Replace(data, []byte(v), []byte(redacted), -1)
+	}
+
+	return passwordPattern.ReplaceAll(data, []byte("${1}"+redacted))
+}
@@ -0,0 +1,353 @@
+// Copyright © 2019 Percona, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+const (
+	certValidity       = 3 * 365 * 24 * time.Hour
+	certKeyBits        = 2048
+	certificateTimeout = 5 * time.Minute
+	certificateType    = "certificate.cert-manager.io"
+)
+
+// TLSSecretNames returns names of the TLS secrets the operators use by default for the cluster
+// with the given name: the secret of the client connections and the secret of the internal traffic
+func TLSSecretNames(clusterName string) []string {
+	return []string{clusterName + "-ssl", clusterName + "-ssl-internal"}
+}
+
+// TLSHosts returns the host names of the certificates of the given cluster services in the namespace
+func TLSHosts(services []string, namespace string) []string {
+	var hosts []string
+	for _, svc := range services {
+		hosts = append(hosts, svc, "*."+svc,
+			svc+"."+namespace, "*."+svc+"."+namespace,
+			svc+"."+namespace+".svc.cluster.local", "*."+svc+"."+namespace+".svc.cluster.local")
+	}
+
+	return hosts
+}
+
+// TLSSecret returns the client TLS secret name set in the cluster object spec by the given fields
+// or the default name if it isn't set
+func TLSSecret(cluster []byte, clusterName string, fields ...string) string {
+	var obj object
+	err := json.Unmarshal(cluster, &obj)
+	if err != nil {
+		return TLSSecretNames(clusterName)[0]
+	}
+	var v interface{} = obj.Spec
+	for _, f := range fields {
+		m, _ := v.(map[string]interface{})
+		v = m[f]
+	}
+	if name, _ := v.(string); len(name) > 0 {
+		return name
+	}
+
+	return TLSSecretNames(clusterName)[0]
+}
+
+// SetupTLS creates the TLS secrets of the new cluster with the given name and services with the given TLS configuration.
+// The secrets and certificates mustn't exist, the created ones are deleted on failure
+func (p Cmd) SetupTLS(clusterName string, tls dbaas.TLS, services []string) error {
+	names := TLSSecretNames(clusterName)
+	for _, name := range names {
+		ext, err := p.IsObjExists("secret", name)
+		if err != nil {
+			return errors.Wrap(err, "check if secret exists")
+		}
+		if ext {
+			return errors.Errorf("secret %s already exists", name)
+		}
+		if !issuerMode(tls) {
+			continue
+		}
+		ext, err = p.IsObjExists(certificateType, name)
+		if err != nil {
+			return errors.Wrap(err, "check if certificate exists, check that cert-manager is installed")
+		}
+		if ext {
+			return errors.Errorf("certificate %s already exists", name)
+		}
+	}
+
+	err := p.setupTLS(clusterName, names, tls, services)
+	if err != nil {
+		derr := p.DeleteTLS(clusterName, tls)
+		if derr != nil {
+			return errors.Errorf("%v; delete TLS secrets: %v", err, derr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteTLS deletes the TLS secrets and certificates SetupTLS creates for the cluster with the given name
+func (p Cmd) DeleteTLS(clusterName string, tls dbaas.TLS) error {
+	for _, name := range TLSSecretNames(clusterName) {
+		// the certificate goes first so cert-manager doesn't recreate its secret
+		if issuerMode(tls) {
+			err := p.deleteIfExists(certificateType, name)
+			if err != nil {
+				return errors.Wrapf(err, "delete certificate %s", name)
+			}
+		}
+		err := p.deleteIfExists("secret", name)
+		if err != nil {
+			return errors.Wrapf(err, "delete secret %s", name)
+		}
+	}
+
+	return nil
+}
+
+func (p Cmd) deleteIfExists(typ, name string) error {
+	ext, err := p.IsObjExists(typ, name)
+	if err != nil || !ext {
+		return err
+	}
+	return p.DeleteObject(typ, name)
+}
+
+func issuerMode(tls dbaas.TLS) bool {
+	return tls.Mode == dbaas.TLSIssuer || tls.Mode == dbaas.TLSClusterIssuer
+}
+
+func (p Cmd) setupTLS(clusterName string, names []string, tls dbaas.TLS, services []string) error {
+	if tls.Mode == dbaas.TLSSecret {
+		return p.CopyTLSSecret(tls.Name, names)
+	}
+
+	ns, err := p.GetCurrentNamespace()
+	if err != nil {
+		return errors.Wrap(err, "get namespace")
+	}
+	if len(ns) == 0 {
+		ns = "default"
+	}
+	hosts := TLSHosts(services, ns)
+	switch tls.Mode {
+	case dbaas.TLSGenerate:
+		return p.GenerateTLSSecrets(names, clusterName, hosts)
+	case dbaas.TLSIssuer:
+		return p.CreateCertificates(names, tls.Name, "Issuer", clusterName, hosts, certificateTimeout)
+	case dbaas.TLSClusterIssuer:
+		return p.CreateCertificates(names, tls.Name, "ClusterIssuer", clusterName, hosts, certificateTimeout)
+	}
+
+	return nil
+}
+
+// GenerateTLSSecrets generates a CA and a certificate signed by it for the given hosts
+// and stores them in the TLS secrets with the given names
+func (p Cmd) GenerateTLSSecrets(names []string, commonName string, hosts []string) error {
+	caCert, caKey, err := generateCA(commonName + "-ca")
+	if err != nil {
+		return errors.Wrap(err, "generate CA")
+	}
+	cert, key, err := generateCert(commonName, hosts, caCert, caKey)
+	if err != nil {
+		return errors.Wrap(err, "generate certificate")
+	}
+	data := map[string][]byte{
+		"ca.crt":                pemEncode("CERTIFICATE", caCert.Raw),
+		corev1.TLSCertKey:       cert,
+		corev1.TLSPrivateKeyKey: pemEncode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key)),
+	}
+	for _, name := range names {
+		err = p.createTLSSecret(name, data)
+		if err != nil {
+			return errors.Wrapf(err, "create secret %s", name)
+		}
+	}
+
+	return nil
+}
+
+// CreateCertificates creates cert-manager certificates issued by the issuer of the given kind (Issuer or ClusterIssuer)
+// for the hosts and waits until cert-manager stores them in the secrets with the given names
+func (p Cmd) CreateCertificates(names []string, issuer, issuerKind, commonName string, hosts []string, timeout time.Duration) error {
+	for _, name := range names {
+		cert := object{
+			APIVersion: "cert-manager.io/v1",
+			Kind:       "Certificate",
+			Metadata:   map[string]interface{}{"name": name},
+			Spec: map[string]interface{}{
+				"secretName": name,
+				"commonName": commonName,
+				"dnsNames":   hosts,
+				"isCA":       false,
+				"issuerRef": map[string]interface{}{
+					"name": issuer,
+					"kind": issuerKind,
+				},
+			},
+		}
+		data, err := json.Marshal(cert)
+		if err != nil {
+			return errors.Wrap(err, "marshal certificate")
+		}
+		err = p.apply(string(data))
+		if err != nil {
+			return errors.Wrapf(err, "apply certificate %s, check that cert-manager is installed", name)
+		}
+	}
+	for _, name := range names {
+		err := p.WaitObject("secret", name, timeout)
+		if err != nil {
+			return errors.Wrap(err, "wait certificate secret")
+		}
+	}
+
+	return nil
+}
+
+// CopyTLSSecret copies the certificate, key and CA of the existing TLS secret to the secrets with the given names
+func (p Cmd) CopyTLSSecret(source string, names []string) error {
+	data, err := p.GetSecrets(source)
+	if err != nil {
+		return errors.Wrapf(err, "get secret %s", source)
+	}
+	for _, k := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if len(data[k]) == 0 {
+			return errors.Errorf("secret %s has no %s", source, k)
+		}
+	}
+	for _, name := range names {
+		err = p.createTLSSecret(name, data)
+		if err != nil {
+			return errors.Wrapf(err, "create secret %s", name)
+		}
+	}
+
+	return nil
+}
+
+func (p Cmd) createTLSSecret(name string, data map[string][]byte) error {
+	ext, err := p.IsObjExists("secret", name)
+	if err != nil {
+		return errors.Wrap(err, "check if secret exists")
+	}
+	if ext {
+		return errors.Errorf("secret %s already exists", name)
+	}
+	s := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Data: data,
+		Type: corev1.SecretTypeTLS,
+	}
+	sj, err := json.Marshal(s)
+	if err != nil {
+		return errors.Wrap(err, "json marshal")
+	}
+
+	return errors.WithMessage(p.apply(string(sj)), "apply")
+}
+
+func generateCA(commonName string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, certKeyBits)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "generate key")
+	}
+	tmpl, err := certTemplate(commonName)
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl.IsCA = true
+	tmpl.BasicConstraintsValid = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "create certificate")
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parse certificate")
+	}
+
+	return cert, key, nil
+}
+
+// generateCert returns PEM encoded certificate signed by the CA and its key.
+// The certificate is used by both servers and clients of the internal traffic
+func generateCert(commonName string, hosts []string, ca *x509.Certificate, caKey *rsa.PrivateKey) ([]byte, *rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, certKeyBits)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "generate key")
+	}
+	tmpl, err := certTemplate(commonName)
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl.DNSNames = hosts
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "create certificate")
+	}
+
+	return pemEncode("CERTIFICATE", der), key, nil
+}
+
+func certTemplate(commonName string) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "generate serial number")
+	}
+	now := time.Now()
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{"Percona"},
+		},
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(certValidity),
+	}, nil
+}
+
+func pemEncode(typ string, der []byte) []byte {
+	var b bytes.Buffer
+	pem.Encode(&b, &pem.Block{Type: typ, Bytes: der})
+
+	return b.Bytes()
+}
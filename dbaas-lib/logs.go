@@ -0,0 +1,23 @@
+package dbaas
+
+import "io"
+
+// LogOptions sets which logs to show
+type LogOptions struct {
+	// Component is the name of the cluster component, logs of all cluster components are shown if it is empty
+	Component string
+	// Follow keeps streaming new log lines
+	Follow bool
+	// Since shows only logs newer than the given relative duration like 5s, 2m or 3h
+	Since string
+}
+
+// StreamLogs writes logs of the DB resource components to w
+func StreamLogs(instance Instance, opts LogOptions, w io.Writer) error {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return err
+	}
+
+	return eng.StreamLogs(instance.Name, opts, w)
+}
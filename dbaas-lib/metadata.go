@@ -0,0 +1,26 @@
+package dbaas
+
+import "github.com/pkg/errors"
+
+// MetadataEngine is implemented by engines which can add labels and annotations to the cluster secrets
+type MetadataEngine interface {
+	// SetSecretsMetadata adds the labels and annotations to the secrets of the cluster.
+	// It waits until the secrets generated for the new cluster exist
+	SetSecretsMetadata(name string, labels, annotations map[string]string) error
+}
+
+func setSecretsMetadata(eng Engine, instance Instance) error {
+	if len(instance.Labels) == 0 && len(instance.Annotations) == 0 {
+		return nil
+	}
+	metaEng, ok := eng.(MetadataEngine)
+	if !ok {
+		return nil
+	}
+	err := metaEng.SetSecretsMetadata(instance.Name, instance.Labels, instance.Annotations)
+	if err != nil {
+		return errors.Wrap(err, "set secrets metadata")
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package options
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	maxSuggestions     = 3
+	maxSuggestDistance = 3
+)
+
+// Option describes an option which can be set with Parse
+type Option struct {
+	Path    string `json:"path"`
+	Type    string `json:"type"`
+	Default string `json:"default,omitempty"`
+}
+
+// List returns all options of the given object sorted by path. Struct options which can't
+// be set with a single value are omitted, their fields are listed instead. Defaults are taken
+// from the object values, slice fields defaults are taken from the first element.
+func List(obj interface{}) []Option {
+	rv := reflect.ValueOf(obj)
+	var list []Option
+	walkKeys(rv.Type(), "", "", func(name, kt string, t reflect.Type) {
+		if name == "" {
+			return
+		}
+		ft := t
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && !isJSONUnmarshaler(ft) {
+			return
+		}
+		list = append(list, Option{
+			Path:    name,
+			Type:    t.String(),
+			Default: defaultValue(rv, strings.Split(kt, ".")),
+		})
+	})
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Path < list[j].Path
+	})
+
+	return list
+}
+
+// defaultValue returns the string representation of the value on the given Go fields path
+func defaultValue(v reflect.Value, fields []string) string {
+	for _, f := range fields {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return ""
+			}
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Slice {
+			if v.Len() == 0 {
+				return ""
+			}
+			v = v.Index(0)
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return ""
+				}
+				v = v.Elem()
+			}
+		}
+		if v.Kind() != reflect.Struct {
+			return ""
+		}
+		v = v.FieldByName(f)
+		if !v.IsValid() {
+			return ""
+		}
+	}
+
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if isZero(v) {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Struct:
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+		return strings.Trim(string(data), `"`)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+	}
+}
+
+// suggest returns the valid keys which are the closest to the given invalid one. Keys with the same
+// last part, e.g. spec.replsets.size for spec.size, are suggested if at most half of the key differs
+func suggest(key string, valid map[string]string) []string {
+	type candidate struct {
+		key  string
+		dist int
+	}
+	last := key[strings.LastIndex(key, ".")+1:]
+	var candidates []candidate
+	for k := range valid {
+		d := distance(key, k)
+		if d > maxSuggestDistance && (k[strings.LastIndex(k, ".")+1:] != last || d > len(k)/2) {
+			continue
+		}
+		candidates = append(candidates, candidate{k, d})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist == candidates[j].dist {
+			return candidates[i].key < candidates[j].key
+		}
+		return candidates[i].dist < candidates[j].dist
+	})
+
+	var s []string
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		s = append(s, candidates[i].key)
+	}
+
+	return s
+}
+
+// distance returns the Levenshtein distance between two strings
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+func min(v ...int) int {
+	m := v[0]
+	for _, i := range v[1:] {
+		if i < m {
+			m = i
+		}
+	}
+
+	return m
+}
@@ -1,15 +1,39 @@
 package options
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
 )
 
+// fileValuePrefix marks option value which is a path to YAML or JSON file with the value
+const fileValuePrefix = "@"
+
 // Parse parses options from the given string in format "object.paramValue=val,objectTwo.paramValue=val"
-// and assigned it into the given "to" of type type
+// and assigned it into the given "to" of type type.
+//
+// Slice elements and map entries are selected in square brackets:
+//
+//	replsets[1].size=3            element with index 1, index equal to the slice length appends an element
+//	replsets[name=rs1].size=3     element which field "name" is "rs1"
+//	replsets[rs1].size=3          the same as [name=rs1]
+//	tolerations[+].key=dedicated  new element appended to the slice
+//	nodeSelector[disktype]=ssd    map entry with key "disktype"
+//
+// If there is no element with the given field value it is added as a copy of the first element
+// with the field set. Without brackets the first element of a slice is used.
+//
+// Values containing "," "=" or ";" can be quoted: env[+].value="a,b". Quoted value is set as is
+// and is never split into slice elements. Structs, slices and maps can be set with YAML or JSON
+// literals: tolerations[+]={key: dedicated, operator: Exists} or sl=[1, 2], or loaded from
+// YAML or JSON file: spec=@cluster.yaml.
 func Parse(to interface{}, typ reflect.Type, options string) error {
 	if options == "" {
 		return nil
@@ -17,55 +41,438 @@ func Parse(to interface{}, typ reflect.Type, options string) error {
 
 	opts := make(map[string]string)
 	validConfKeys(typ, opts, "", "")
-	optArr := strings.Split(options, ",")
-	for _, str := range optArr {
-		v := strings.Split(str, "=")
-		v[0] = strings.ToLower(v[0])
-		if _, ok := opts[getStringWithoutSquareBrackets(v[0])]; !ok {
-			return errors.Errorf("invalid option %s", v[0])
-		}
-		if len(v) > 1 {
-			fs := strings.Split(opts[getStringWithoutSquareBrackets(v[0])], ".")
-			rv := reflect.ValueOf(to).Elem()
-			for _, f := range fs {
-				if rv.Kind() == reflect.Slice {
-					if reflect.Indirect(rv.Addr()).Len() > 0 {
-						rv = rv.Index(0).Elem().FieldByName(f)
-						continue
-					}
-				} else if rv.Kind() == reflect.Interface {
-					rv = reflect.Indirect(rv.Elem()).FieldByName(f)
-				} else if rv.Kind() == reflect.Ptr {
-					if rv.IsZero() {
-						nv := reflect.New(rv.Type().Elem())
-						rv.Set(nv)
-					}
-					rv = reflect.Indirect(rv).FieldByName(f)
-				} else {
-					rv = rv.FieldByName(f)
+	optArr, err := splitOptions(options)
+	if err != nil {
+		return err
+	}
+	for _, o := range optArr {
+		segments, selectors, err := parseKey(o.key)
+		if err != nil {
+			return errors.Wrapf(err, "invalid option %s", o.key)
+		}
+		key := strings.ToLower(strings.Join(segments, "."))
+		if _, ok := opts[key]; !ok {
+			return &InvalidOptionError{Key: strings.ToLower(o.key), Suggestions: suggest(key, opts)}
+		}
+		if !o.hasValue {
+			continue
+		}
+		t, err := fieldValue(reflect.ValueOf(to).Elem(), strings.Split(opts[key], "."), selectors)
+		if err != nil {
+			return errors.Wrapf(err, "option %s", o.key)
+		}
+		if o.quoted {
+			err = setQuotedValue(t.value, o.value)
+		} else {
+			err = setValue(t.value, o.value)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "set value %s=%s", o.key, o.value)
+		}
+		t.store()
+	}
+
+	return nil
+}
+
+// AddPrefix adds the prefix to the key of every option in the options string
+func AddPrefix(opts, prefix string) string {
+	var prefixed []string
+	for len(opts) > 0 {
+		_, rest, err := nextOption(opts)
+		if err != nil {
+			// the option is kept as is for Parse to report the error
+			prefixed = append(prefixed, prefix+opts)
+			break
+		}
+		raw := strings.TrimSuffix(opts[:len(opts)-len(rest)], ",")
+		if len(raw) > 0 {
+			prefixed = append(prefixed, prefix+raw)
+		}
+		opts = rest
+	}
+
+	return strings.Join(prefixed, ",")
+}
+
+// Quote quotes the option value so Parse sets it as is
+func Quote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// InvalidOptionError is returned by Parse for an option which the object doesn't have
+type InvalidOptionError struct {
+	Key         string
+	Suggestions []string
+}
+
+func (e *InvalidOptionError) Error() string {
+	if len(e.Suggestions) > 0 {
+		return fmt.Sprintf("invalid option %s, did you mean %s?", e.Key, strings.Join(e.Suggestions, ", "))
+	}
+
+	return "invalid option " + e.Key
+}
+
+// TrimPrefix removes the prefix added with AddPrefix from the option key and the suggestions,
+// so they are reported the way they were passed. Suggestions without the prefix are dropped
+func (e *InvalidOptionError) TrimPrefix(prefix string) {
+	e.Key = strings.TrimPrefix(e.Key, prefix)
+	var suggestions []string
+	for _, s := range e.Suggestions {
+		if strings.HasPrefix(s, prefix) {
+			suggestions = append(suggestions, strings.TrimPrefix(s, prefix))
+		}
+	}
+	e.Suggestions = suggestions
+}
+
+type option struct {
+	key      string
+	value    string
+	hasValue bool
+	quoted   bool
+}
+
+// splitOptions splits options string by commas which are not inside square brackets of the key,
+// quotes or value literals
+func splitOptions(s string) ([]option, error) {
+	var opts []option
+	for len(s) > 0 {
+		o, rest, err := nextOption(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(o.key) > 0 || o.hasValue {
+			opts = append(opts, o)
+		}
+		s = rest
+	}
+
+	return opts, nil
+}
+
+func nextOption(s string) (option, string, error) {
+	var o option
+	depth := 0
+	i := 0
+key:
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '=', ',':
+			if depth <= 0 {
+				break key
+			}
+		}
+	}
+	o.key = s[:i]
+	if i == len(s) {
+		return o, "", nil
+	}
+	if s[i] == ',' {
+		return o, s[i+1:], nil
+	}
+
+	s = s[i+1:]
+	o.hasValue = true
+	if len(s) > 0 && (s[0] == '"' || s[0] == '\'') {
+		value, rest, err := unquote(s)
+		if err != nil {
+			return o, "", errors.Wrapf(err, "option %s", o.key)
+		}
+		if len(rest) > 0 && rest[0] != ',' {
+			return o, "", errors.Errorf("option %s: unexpected %s after quoted value", o.key, rest)
+		}
+		o.value = value
+		o.quoted = true
+		return o, strings.TrimPrefix(rest, ","), nil
+	}
+
+	depth = 0
+	var quote byte
+	for i = 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			// quotes are meaningful only inside literals, e.g. {key: "a,b"}
+			if depth > 0 {
+				quote = c
+			}
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case ',':
+			if depth <= 0 {
+				o.value = s[:i]
+				return o, s[i+1:], nil
+			}
+		}
+	}
+	if quote != 0 || depth > 0 {
+		return o, "", errors.Errorf("option %s: unterminated value %s", o.key, s)
+	}
+	o.value = s
+
+	return o, "", nil
+}
+
+// unquote returns the quoted string without quotes and the rest of s. Backslash escapes the next character
+func unquote(s string) (string, string, error) {
+	quote := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case quote:
+			return b.String(), s[i+1:], nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return "", "", errors.New("unterminated quoted value")
+}
+
+// parseKey splits option key into parts and returns them with the content of square brackets of each part
+func parseKey(key string) ([]string, []string, error) {
+	var segments, selectors []string
+	for len(key) > 0 {
+		end := strings.IndexAny(key, ".[")
+		if end < 0 {
+			segments = append(segments, key)
+			selectors = append(selectors, "")
+			break
+		}
+		segment := key[:end]
+		selector := ""
+		if key[end] == '[' {
+			closing := strings.Index(key[end:], "]")
+			if closing < 0 {
+				return nil, nil, errors.New("no closing bracket")
+			}
+			selector = key[end+1 : end+closing]
+			if len(selector) == 0 {
+				return nil, nil, errors.New("empty brackets")
+			}
+			end += closing + 1
+			if end < len(key) && key[end] != '.' {
+				return nil, nil, errors.Errorf("unexpected %s after brackets", key[end:])
+			}
+		}
+		if len(segment) == 0 {
+			return nil, nil, errors.New("empty key part")
+		}
+		segments = append(segments, segment)
+		selectors = append(selectors, selector)
+		key = strings.TrimPrefix(key[end:], ".")
+	}
+
+	return segments, selectors, nil
+}
+
+// fieldValue walks through the struct fields with the given names allocating nil pointers on its way.
+// Slice elements and map entries are chosen with selectors of the corresponding key parts
+func fieldValue(rv reflect.Value, fields, selectors []string) (target, error) {
+	for i, f := range fields {
+		var err error
+		rv, err = indirect(rv)
+		if err != nil {
+			return target{}, err
+		}
+		rv = rv.FieldByName(f)
+		if !rv.IsValid() {
+			return target{}, errors.Errorf("no field %s", f)
+		}
+		if i < len(selectors) && len(selectors[i]) > 0 {
+			rv, err = indirect(rv)
+			if err != nil {
+				return target{}, err
+			}
+			switch rv.Kind() {
+			case reflect.Slice:
+				rv, err = sliceElement(rv, selectors[i])
+			case reflect.Map:
+				if i < len(fields)-1 {
+					return target{}, errors.Errorf("fields of %s values can't be set separately", f)
 				}
+				return mapTarget(rv, selectors[i])
+			default:
+				err = errors.Errorf("%s is not a list or a map", f)
+			}
+			if err != nil {
+				return target{}, err
 			}
-			err := setValue(rv, v[1])
+			continue
+		}
+		if i < len(fields)-1 && rv.Kind() == reflect.Slice {
+			rv, err = sliceElement(rv, "")
 			if err != nil {
-				return errors.Wrapf(err, "set value %s=%s", v[0], v[1])
+				return target{}, err
 			}
 		}
 	}
 
-	return nil
+	return target{value: rv}, nil
+}
+
+func indirect(rv reflect.Value) (reflect.Value, error) {
+	for rv.Kind() == reflect.Interface || rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			if rv.Kind() == reflect.Interface || !rv.CanSet() {
+				return rv, errors.New("nil value")
+			}
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+
+	return rv, nil
+}
+
+// sliceElement returns the slice element chosen by the selector which is an index, "+" for a new element,
+// "field=value" or just a name for "name=value". The first element is returned if the selector is empty
+func sliceElement(slice reflect.Value, selector string) (reflect.Value, error) {
+	switch {
+	case selector == "":
+		if slice.Len() == 0 {
+			slice.Set(reflect.Append(slice, newElement(slice.Type().Elem())))
+		}
+		return slice.Index(0), nil
+	case selector == "+":
+		slice.Set(reflect.Append(slice, newElement(slice.Type().Elem())))
+		return slice.Index(slice.Len() - 1), nil
+	}
+
+	if i, err := strconv.Atoi(selector); err == nil {
+		switch {
+		case i >= 0 && i < slice.Len():
+			return slice.Index(i), nil
+		case i == slice.Len():
+			slice.Set(reflect.Append(slice, newElement(slice.Type().Elem())))
+			return slice.Index(i), nil
+		default:
+			return slice, errors.Errorf("index %d is out of range, the list has %d elements", i, slice.Len())
+		}
+	}
+
+	field, value := "name", selector
+	if kv := strings.SplitN(selector, "=", 2); len(kv) == 2 {
+		field, value = kv[0], kv[1]
+	}
+	for i := 0; i < slice.Len(); i++ {
+		el, err := indirect(slice.Index(i))
+		if err != nil {
+			return el, err
+		}
+		f, err := fieldByJSONName(el, field)
+		if err != nil {
+			return f, err
+		}
+		if fmt.Sprint(f.Interface()) == value {
+			return slice.Index(i), nil
+		}
+	}
+
+	el := newElement(slice.Type().Elem())
+	if slice.Len() > 0 {
+		// copy the first element to keep its default settings
+		data, err := json.Marshal(slice.Index(0).Interface())
+		if err != nil {
+			return el, errors.Wrap(err, "marshal element")
+		}
+		ptr := el
+		if el.Kind() != reflect.Ptr {
+			ptr = el.Addr()
+		}
+		err = json.Unmarshal(data, ptr.Interface())
+		if err != nil {
+			return el, errors.Wrap(err, "unmarshal element")
+		}
+	}
+	f, err := fieldByJSONName(reflect.Indirect(el), field)
+	if err != nil {
+		return f, err
+	}
+	err = setValue(f, value)
+	if err != nil {
+		return f, err
+	}
+	slice.Set(reflect.Append(slice, el))
+
+	return slice.Index(slice.Len() - 1), nil
 }
 
-func getStringWithoutSquareBrackets(s string) string {
-	if strings.Contains(s, "[") && strings.Contains(s, "]") {
-		startIndex := strings.Index(s, "[")
-		endIndex := strings.Index(s, "]")
-		if startIndex > 0 && endIndex > 0 {
-			newVal := s[:startIndex] + s[endIndex+1:]
-			return newVal
+// fieldByJSONName returns the struct field which json or Go name is equal to the given name ignoring case
+func fieldByJSONName(v reflect.Value, name string) (reflect.Value, error) {
+	if v.Kind() != reflect.Struct {
+		return v, errors.Errorf("elements of %s type can be selected only by index", v.Type())
+	}
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+		if strings.EqualFold(jsonName, name) || strings.EqualFold(f.Name, name) {
+			return v.Field(i), nil
 		}
 	}
 
-	return s
+	return v, errors.Errorf("%s has no field %s", v.Type(), name)
+}
+
+// target is the value set by an option. Map entries are not addressable, so the value
+// is set to a copy which is stored into the map afterwards
+type target struct {
+	value reflect.Value
+	m     reflect.Value
+	key   reflect.Value
+}
+
+func mapTarget(m reflect.Value, key string) (target, error) {
+	if m.IsNil() {
+		m.Set(reflect.MakeMap(m.Type()))
+	}
+	k := reflect.New(m.Type().Key()).Elem()
+	err := setValue(k, key)
+	if err != nil {
+		return target{}, errors.Wrap(err, "map key")
+	}
+	v := reflect.New(m.Type().Elem()).Elem()
+	if old := m.MapIndex(k); old.IsValid() {
+		v.Set(old)
+	}
+
+	return target{value: v, m: m, key: k}, nil
+}
+
+func (t target) store() {
+	if t.m.IsValid() {
+		t.m.SetMapIndex(t.key, t.value)
+	}
+}
+
+// newElement returns new addressable value of the given type allocating the value if it is a pointer
+func newElement(t reflect.Type) reflect.Value {
+	if t.Kind() == reflect.Ptr {
+		return reflect.New(t.Elem())
+	}
+
+	return reflect.New(t).Elem()
 }
 
 func setValue(val reflect.Value, value string) error {
@@ -76,60 +483,240 @@ func setValue(val reflect.Value, value string) error {
 		val = reflect.Indirect(val)
 	}
 
+	if strings.HasPrefix(value, fileValuePrefix) {
+		switch val.Kind() {
+		case reflect.Struct, reflect.Slice, reflect.Map:
+			return setValueFromFile(val, strings.TrimPrefix(value, fileValuePrefix))
+		}
+	}
+	if isLiteral(val, value) {
+		return setLiteral(val, []byte(value))
+	}
+	if isJSONUnmarshaler(val.Type()) {
+		// types like resource.Quantity are set from their string representation
+		err := json.Unmarshal([]byte(strconv.Quote(value)), val.Addr().Interface())
+		if err != nil {
+			err = json.Unmarshal([]byte(value), val.Addr().Interface())
+		}
+		if err != nil {
+			return errors.Errorf("parse value %s: %v", value, err)
+		}
+		return nil
+	}
+
 	switch val.Kind() {
 	default:
-		// TODO: maps, slices
 		return errors.Errorf("type %v not implemented", val.Kind())
 	case reflect.Struct:
-	/*v, err := parseStructValue(value, val)
-	if err != nil {
-		return errors.Errorf("parse value %s: %v", val, err)
-	}
-	val.Set(v)*/
+		return errors.Errorf("use {field: value} literal or %spath/to/file.yaml to set the whole object", fileValuePrefix)
 	case reflect.Map:
 		v, err := parseMapValue(value, val)
 		if err != nil {
-			return errors.Errorf("parse value %s: %v", val, err)
+			return errors.Errorf("parse value %s: %v", value, err)
 		}
 		val.Set(v)
 	case reflect.Slice:
 		v, err := parseSliceValue(value, val)
 		if err != nil {
-			return errors.Errorf("parse value %s: %v", val, err)
+			return errors.Errorf("parse value %s: %v", value, err)
 		}
 		val.Set(v)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		v, err := strconv.ParseInt(value, 10, 64)
 		if err != nil || val.OverflowInt(v) {
-			return errors.Errorf("parse value %s: %v", val, err)
+			return errors.Errorf("parse value %s: %v", value, err)
 		}
 		val.SetInt(v)
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		v, err := strconv.ParseUint(value, 10, 64)
 		if err != nil || val.OverflowUint(v) {
-			return errors.Errorf("parse value %s: %v", val, err)
+			return errors.Errorf("parse value %s: %v", value, err)
 		}
 		val.SetUint(v)
 	case reflect.Float32, reflect.Float64:
 		v, err := strconv.ParseFloat(value, 64)
 		if err != nil || val.OverflowFloat(v) {
-			return errors.Errorf("parse value %s: %v", val, err)
+			return errors.Errorf("parse value %s: %v", value, err)
 		}
 		val.SetFloat(v)
 	case reflect.Bool:
 		v, err := strconv.ParseBool(value)
 		if err != nil {
-			return errors.Errorf("parse value %s: %v", val, err)
+			return errors.Errorf("parse value %s: %v", value, err)
 		}
 		val.SetBool(v)
 	case reflect.String:
 		val.SetString(value)
+	}
 
+	return nil
+}
+
+// setQuotedValue sets the value as is. A quoted value for a slice becomes its new element
+func setQuotedValue(val reflect.Value, value string) error {
+	val, err := indirect(val)
+	if err != nil {
+		return err
+	}
+	switch {
+	case val.Kind() == reflect.Slice && !isJSONUnmarshaler(val.Type()):
+		el := newElement(val.Type().Elem())
+		err = setQuotedValue(el, value)
+		if err != nil {
+			return err
+		}
+		val.Set(reflect.Append(val, el))
+		return nil
+	case val.Kind() == reflect.String:
+		val.SetString(value)
+		return nil
+	case val.Kind() == reflect.Map:
+		return errors.New("quoted value can't be used for a map, set its entries with map[key]=value")
+	}
+
+	return setValue(val, value)
+}
+
+// isLiteral returns true if the value is YAML or JSON literal of the struct, slice or map
+func isLiteral(val reflect.Value, value string) bool {
+	switch val.Kind() {
+	case reflect.Struct, reflect.Map:
+		return strings.HasPrefix(value, "{")
+	case reflect.Slice:
+		return strings.HasPrefix(value, "[")
+	}
+
+	return false
+}
+
+func setLiteral(val reflect.Value, data []byte) error {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return errors.Wrap(err, "parse literal")
+	}
+	if val.Kind() == reflect.Slice {
+		// literal replaces the whole list instead of merging into its elements
+		val.Set(reflect.Zero(val.Type()))
+	}
+	err = json.Unmarshal(data, val.Addr().Interface())
+	if err != nil {
+		return errors.Wrap(err, "parse literal")
 	}
 
 	return nil
 }
 
+// setValueFromFile unmarshals YAML or JSON file into the value. Fields which are not set in the file keep their values.
+// New elements of the value lists get the values of the first element as sliceElement does
+func setValueFromFile(val reflect.Value, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "read file")
+	}
+	if val.Kind() == reflect.Slice {
+		err = setLiteral(val, data)
+		if err != nil {
+			return errors.Wrapf(err, "parse %s", path)
+		}
+		return nil
+	}
+
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return errors.Wrapf(err, "parse %s", path)
+	}
+	patch, err := decodeJSON(data)
+	if err != nil {
+		return errors.Wrapf(err, "parse %s", path)
+	}
+	data, err = json.Marshal(val.Interface())
+	if err != nil {
+		return errors.Wrap(err, "marshal value")
+	}
+	base, err := decodeJSON(data)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal value")
+	}
+	data, err = json.Marshal(mergeJSON(base, patch))
+	if err != nil {
+		return errors.Wrap(err, "marshal value")
+	}
+	val.Set(reflect.Zero(val.Type()))
+	err = json.Unmarshal(data, val.Addr().Interface())
+	if err != nil {
+		return errors.Wrapf(err, "parse %s", path)
+	}
+
+	return nil
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	err := d.Decode(&v)
+
+	return v, err
+}
+
+// mergeJSON merges decoded JSON patch into base. Objects are merged key by key, list elements are merged
+// into the base elements with the same index and the new ones into a copy of the first base element
+func mergeJSON(base, patch interface{}) interface{} {
+	switch p := patch.(type) {
+	case map[string]interface{}:
+		b, ok := base.(map[string]interface{})
+		if !ok {
+			return p
+		}
+		for k, v := range p {
+			b[k] = mergeJSON(b[k], v)
+		}
+		return b
+	case []interface{}:
+		b, ok := base.([]interface{})
+		if !ok || len(b) == 0 {
+			return p
+		}
+		first := copyJSON(b[0])
+		for i, v := range p {
+			if i < len(b) {
+				p[i] = mergeJSON(b[i], v)
+			} else {
+				p[i] = mergeJSON(copyJSON(first), v)
+			}
+		}
+		return p
+	default:
+		return patch
+	}
+}
+
+func copyJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[k] = copyJSON(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(t))
+		for i, e := range t {
+			s[i] = copyJSON(e)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func isJSONUnmarshaler(t reflect.Type) bool {
+	return t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(jsonUnmarshalerType)
+}
+
+// parseMapValue parses map in "key1:value1;key2:value2" format
 func parseMapValue(s string, refValue reflect.Value) (reflect.Value, error) {
 	value := reflect.MakeMap(refValue.Type())
 
@@ -138,30 +725,33 @@ func parseMapValue(s string, refValue reflect.Value) (reflect.Value, error) {
 		return value, errors.New("empty value")
 	}
 
-	keyValue := reflect.Indirect(reflect.New(value.Type().Key()))
-	mapValue := reflect.Indirect(reflect.New(value.Type().Elem()))
 	for _, v := range sSlice {
-		vSlice := strings.Split(v, ":")
+		vSlice := strings.SplitN(v, ":", 2)
 		if len(vSlice) != 2 {
 			return value, errors.New("empty map value")
 		}
-		setValue(keyValue, vSlice[0])
-		setValue(mapValue, vSlice[1])
+		keyValue := reflect.New(value.Type().Key()).Elem()
+		err := setValue(keyValue, vSlice[0])
+		if err != nil {
+			return value, err
+		}
+		mapValue := reflect.New(value.Type().Elem()).Elem()
+		err = setValue(mapValue, vSlice[1])
+		if err != nil {
+			return value, err
+		}
 		value.SetMapIndex(keyValue, mapValue)
 	}
 
 	return value, nil
 }
 
+// parseSliceValue parses list in "value1;value2" format. The list replaces the current slice
 func parseSliceValue(s string, refValue reflect.Value) (reflect.Value, error) {
-
-	value := refValue
 	sSlice := strings.Split(s, ";")
-	if len(sSlice) == 0 {
-		return value, errors.New("empty value")
-	}
+	value := reflect.MakeSlice(refValue.Type(), 0, len(sSlice))
 	for _, v := range sSlice {
-		sliceValue := reflect.Indirect(reflect.New(refValue.Addr().Type().Elem().Elem()))
+		sliceValue := newElement(refValue.Type().Elem())
 		err := setValue(sliceValue, v)
 		if err != nil {
 			return value, err
@@ -172,59 +762,65 @@ func parseSliceValue(s string, refValue reflect.Value) (reflect.Value, error) {
 	return value, nil
 }
 
-func parseStructSliceValue(s string, refValue reflect.Value, fieldName string) (reflect.Value, error) {
-	value := refValue
-	sliceValue := reflect.Indirect(reflect.New(refValue.Addr().Type().Elem().Elem()))
-
-	err := setValue(sliceValue.FieldByName(fieldName), s)
-	if err != nil {
-		return value, err
-	}
-	value = reflect.Append(value, sliceValue)
-	refValue.Set(value)
-	return value, nil
-}
-
-func parseStructValue(s string, refValue reflect.Value) (reflect.Value, error) {
-
-	valueField := refValue.FieldByName(refValue.Type().Name())
-
-	//setValue(valueField, s)
-
-	return valueField, nil
+func validConfKeys(t reflect.Type, to map[string]string, pk, pv string) {
+	walkKeys(t, pk, pv, func(name, kt string, _ reflect.Type) {
+		to[strings.ToLower(name)] = kt
+	})
 }
 
-func validConfKeys(t reflect.Type, to map[string]string, pk, pv string) {
+// walkKeys calls fn for every option path of the type with the corresponding Go fields path and the field type
+func walkKeys(t reflect.Type, pk, pv string, fn func(name, kt string, t reflect.Type)) {
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
-	if t.Kind() != reflect.Struct {
-		to[strings.ToLower(pk)] = pv
+	if t.Kind() != reflect.Struct || isJSONUnmarshaler(t) {
+		fn(pk, pv, t)
 		return
 	}
 	for i := 0; i < t.NumField(); i++ {
-		name := strings.TrimSpace(strings.Split(t.Field(i).Tag.Get("json"), ",")[0])
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		if field.PkgPath != "" || tag[0] == "-" {
+			continue
+		}
+		name := strings.TrimSpace(tag[0])
+		// fields of embedded structs without json name are inlined by JSON marshaling
+		if name == "" && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			walkKeys(field.Type, pk, pv, fn)
+			continue
+		}
 		if name == "" {
-			name = t.Field(i).Name
+			name = field.Name
 		}
 		if pk != "" {
 			name = pk + "." + name
 		}
-		kt := t.Field(i).Name
+		kt := field.Name
 		if pv != "" {
 			kt = pv + "." + kt
 		}
-		fieldType := t.Field(i).Type
-		if t.Field(i).Type.Kind() == reflect.Ptr {
-			fieldType = t.Field(i).Type.Elem()
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
 		}
 
-		if fieldType.Kind() == reflect.Struct {
-			validConfKeys(fieldType, to, name, kt)
-		} else if fieldType.Kind() == reflect.Slice {
-			validConfKeys(fieldType.Elem(), to, name, kt)
-		} else {
-			to[strings.ToLower(name)] = kt
+		switch {
+		case isJSONUnmarshaler(fieldType):
+			fn(name, kt, field.Type)
+		case fieldType.Kind() == reflect.Struct:
+			fn(name, kt, field.Type)
+			walkKeys(fieldType, name, kt, fn)
+		case fieldType.Kind() == reflect.Slice:
+			fn(name, kt, field.Type)
+			elem := fieldType.Elem()
+			if elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Struct && !isJSONUnmarshaler(elem) {
+				walkKeys(elem, name, kt, fn)
+			}
+		default:
+			fn(name, kt, field.Type)
 		}
 	}
 }
@@ -1,6 +1,8 @@
 package options_test
 
 import (
+	"io/ioutil"
+	"os"
 	"reflect"
 	"testing"
 
@@ -61,3 +63,291 @@ func TestOptions(t *testing.T) {
 		t.Errorf("not equal: %v", v)
 	}
 }
+
+func TestOptionsKeyedSlice(t *testing.T) {
+	type Item struct {
+		Name string `json:"name"`
+		Size int32  `json:"size"`
+		Tag  string `json:"tag"`
+	}
+	type T struct {
+		Items []*Item `json:"items"`
+	}
+
+	v := T{Items: []*Item{{Name: "rs0", Size: 3, Tag: "default"}}}
+	err := options.Parse(&v, reflect.TypeOf(v), "items.tag=first,items[rs1].size=5,items[rs0].size=1")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	cmp := T{Items: []*Item{
+		{Name: "rs0", Size: 1, Tag: "first"},
+		{Name: "rs1", Size: 5, Tag: "first"},
+	}}
+	if !reflect.DeepEqual(cmp, v) {
+		t.Errorf("not equal: %+v %+v", *v.Items[0], *v.Items[1])
+	}
+}
+
+func TestOptionsPaths(t *testing.T) {
+	type Env struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	type Toleration struct {
+		Key      string `json:"key"`
+		Operator string `json:"operator"`
+	}
+	type Common struct {
+		NodeSelector map[string]string `json:"nodeSelector"`
+		Tolerations  []Toleration      `json:"tolerations"`
+	}
+	type Replset struct {
+		Name string `json:"name"`
+		Size int32  `json:"size"`
+		Env  []Env  `json:"env"`
+		Common
+	}
+	type Spec struct {
+		Replsets []*Replset `json:"replsets"`
+		Args     []string   `json:"args"`
+		Image    string     `json:"image"`
+	}
+	type CR struct {
+		Spec Spec `json:"spec"`
+	}
+
+	defaults := func() CR {
+		return CR{Spec: Spec{Replsets: []*Replset{{Name: "rs0", Size: 3}}}}
+	}
+
+	tests := []struct {
+		name    string
+		opts    string
+		want    func(cr *CR)
+		wantErr bool
+	}{
+		{
+			name: "first element without brackets",
+			opts: "spec.replsets.size=5",
+			want: func(cr *CR) { cr.Spec.Replsets[0].Size = 5 },
+		},
+		{
+			name: "index",
+			opts: "spec.replsets[0].size=1,spec.replsets[1].name=rs1",
+			want: func(cr *CR) {
+				cr.Spec.Replsets[0].Size = 1
+				cr.Spec.Replsets = append(cr.Spec.Replsets, &Replset{Name: "rs1"})
+			},
+		},
+		{
+			name:    "index out of range",
+			opts:    "spec.replsets[2].size=1",
+			wantErr: true,
+		},
+		{
+			name: "key selector copies the first element",
+			opts: "spec.replsets[name=rs1].env[+].name=A,spec.replsets[rs0].size=1",
+			want: func(cr *CR) {
+				cr.Spec.Replsets[0].Size = 1
+				cr.Spec.Replsets = append(cr.Spec.Replsets, &Replset{Name: "rs1", Size: 3, Env: []Env{{Name: "A"}}})
+			},
+		},
+		{
+			name:    "key selector with unknown field",
+			opts:    "spec.replsets[role=rs1].size=1",
+			wantErr: true,
+		},
+		{
+			name: "quoted values",
+			opts: `spec.image="img:1,2",spec.replsets.env[+].value='a=b;c',spec.args="--x;y",spec.args[+]="z\"q"`,
+			want: func(cr *CR) {
+				cr.Spec.Image = "img:1,2"
+				cr.Spec.Replsets[0].Env = []Env{{Value: "a=b;c"}}
+				cr.Spec.Args = []string{"--x;y", `z"q`}
+			},
+		},
+		{
+			name:    "unterminated quote",
+			opts:    `spec.image="img`,
+			wantErr: true,
+		},
+		{
+			name: "slice replaces and appends",
+			opts: "spec.args=a;b,spec.args[+]=c,spec.args[0]=d",
+			want: func(cr *CR) { cr.Spec.Args = []string{"d", "b", "c"} },
+		},
+		{
+			name: "struct literals",
+			opts: `spec.replsets.tolerations[+]={key: dedicated, operator: "Exists"},spec.replsets.env=[{name: A, value: "1,2"}]`,
+			want: func(cr *CR) {
+				cr.Spec.Replsets[0].Tolerations = []Toleration{{Key: "dedicated", Operator: "Exists"}}
+				cr.Spec.Replsets[0].Env = []Env{{Name: "A", Value: "1,2"}}
+			},
+		},
+		{
+			name: "map entries of inlined struct",
+			opts: "spec.replsets.nodeSelector[disktype]=ssd,spec.replsets.nodeselector[kubernetes.io/os]=linux",
+			want: func(cr *CR) {
+				cr.Spec.Replsets[0].NodeSelector = map[string]string{"disktype": "ssd", "kubernetes.io/os": "linux"}
+			},
+		},
+		{
+			name:    "unknown option",
+			opts:    "spec.replsets.sise=1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := defaults()
+			err := options.Parse(&v, reflect.TypeOf(v), tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			cmp := defaults()
+			tt.want(&cmp)
+			if !reflect.DeepEqual(cmp, v) {
+				t.Errorf("not equal:\n got: %+v\nwant: %+v", v, cmp)
+			}
+		})
+	}
+}
+
+func TestAddPrefix(t *testing.T) {
+	got := options.AddPrefix(`image="a,b",replsets[name=rs1].size=3,tolerations[+]={key: a, operator: Exists}`, "spec.")
+	want := `spec.image="a,b",spec.replsets[name=rs1].size=3,spec.tolerations[+]={key: a, operator: Exists}`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	type CR struct {
+		Image string `json:"image"`
+	}
+
+	want := `a,b="c"\d`
+	v := CR{}
+	err := options.Parse(&v, reflect.TypeOf(v), "image="+options.Quote(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Image != want {
+		t.Errorf("got %s, want %s", v.Image, want)
+	}
+}
+
+func TestOptionsFromFile(t *testing.T) {
+	type Replset struct {
+		Name      string `json:"name"`
+		Size      int32  `json:"size"`
+		MemoryReq string `json:"memory"`
+	}
+	type Spec struct {
+		Replsets []*Replset `json:"replsets"`
+		Image    string     `json:"image"`
+	}
+	type CR struct {
+		Spec Spec `json:"spec"`
+	}
+
+	f, err := ioutil.TempFile("", "spec-*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("replsets:\n- name: rs0\n- name: rs1\n  size: 5\n")
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := CR{Spec: Spec{Replsets: []*Replset{{Name: "rs0", Size: 3, MemoryReq: "1G"}}, Image: "mongo:4.0"}}
+	err = options.Parse(&v, reflect.TypeOf(v), "spec=@"+f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := CR{Spec: Spec{
+		Replsets: []*Replset{{Name: "rs0", Size: 3, MemoryReq: "1G"}, {Name: "rs1", Size: 5, MemoryReq: "1G"}},
+		Image:    "mongo:4.0",
+	}}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("not equal:\n got: %+v\nwant: %+v", v.Spec.Replsets[1], want.Spec.Replsets[1])
+	}
+}
+
+func TestList(t *testing.T) {
+	type Replset struct {
+		Name string `json:"name"`
+		Size int32  `json:"size"`
+	}
+	type Spec struct {
+		Replsets []*Replset        `json:"replsets"`
+		Image    string            `json:"image"`
+		Labels   map[string]string `json:"labels"`
+	}
+	type CR struct {
+		Spec Spec `json:"spec"`
+	}
+
+	v := CR{Spec: Spec{Replsets: []*Replset{{Name: "rs0", Size: 3}}, Image: "mongo:4.0"}}
+	got := options.List(&v)
+	want := []options.Option{
+		{Path: "spec.image", Type: "string", Default: "mongo:4.0"},
+		{Path: "spec.labels", Type: "map[string]string"},
+		{Path: "spec.replsets", Type: "[]*options_test.Replset", Default: `[{"name":"rs0","size":3}]`},
+		{Path: "spec.replsets.name", Type: "string", Default: "rs0"},
+		{Path: "spec.replsets.size", Type: "int32", Default: "3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("not equal:\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestInvalidOptionSuggestion(t *testing.T) {
+	type Replset struct {
+		Size int32 `json:"size"`
+	}
+	type Spec struct {
+		Image    string    `json:"image"`
+		Replsets []Replset `json:"replsets"`
+		Backup   struct {
+			Schedule []struct {
+				Keep int `json:"keep"`
+			} `json:"schedule"`
+		} `json:"backup"`
+	}
+	type CR struct {
+		Spec Spec `json:"spec"`
+	}
+
+	tests := []struct {
+		opts string
+		want string
+	}{
+		{"spec.imgae=mongo", "invalid option imgae, did you mean image?"},
+		{"spec.size=3", "invalid option size, did you mean replsets.size?"},
+		{"spec.backup.schedule.replsets.size=3", "invalid option backup.schedule.replsets.size"},
+	}
+	for _, tt := range tests {
+		v := CR{}
+		err := options.Parse(&v, reflect.TypeOf(v), tt.opts)
+		e, ok := err.(*options.InvalidOptionError)
+		if !ok {
+			t.Errorf("got error %v, want %s", err, tt.want)
+			continue
+		}
+		e.TrimPrefix("spec.")
+		if e.Error() != tt.want {
+			t.Errorf("got %s, want %s", e.Error(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package dbaas
+
+import "github.com/pkg/errors"
+
+// MonitoringEngine is implemented by engines which keep the PMM server credentials in the cluster secrets
+type MonitoringEngine interface {
+	// SetPMMCredentials stores the PMM server credentials in the cluster secrets. Empty values aren't changed.
+	// It is called before a new cluster is created, so the secrets are created if they don't exist
+	SetPMMCredentials(name, user, password string) error
+}
+
+func setPMMCredentials(eng Engine, instance Instance) error {
+	if len(instance.PMMUser) == 0 && len(instance.PMMPassword) == 0 {
+		return nil
+	}
+	monitoring, ok := eng.(MonitoringEngine)
+	if !ok {
+		return errors.Errorf("engine %s doesn't support PMM credentials", instance.Engine)
+	}
+	err := monitoring.SetPMMCredentials(instance.Name, instance.PMMUser, instance.PMMPassword)
+	if err != nil {
+		return errors.Wrap(err, "set PMM credentials")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package dbaas
+
+import "github.com/pkg/errors"
+
+// ErrDeletionProtected is returned on the deletion of the DB resource with the deletion protection enabled
+var ErrDeletionProtected = errors.New("deletion protection is enabled, disable it to delete the database")
+
+// ProtectedEngine is implemented by engines which can protect the cluster from deletion
+type ProtectedEngine interface {
+	// SetDeletionProtection enables or disables the deletion protection of the cluster
+	SetDeletionProtection(name string, enabled bool) error
+	// DeletionProtected returns true if the cluster is protected from deletion
+	DeletionProtected(name string) (bool, error)
+}
+
+// SetDeletionProtection enables or disables the deletion protection of the instance DB resource
+func SetDeletionProtection(instance Instance, enabled bool) error {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return err
+	}
+
+	return setDeletionProtection(eng, instance.Name, enabled)
+}
+
+// DeletionProtected returns true if the instance DB resource is protected from deletion
+func DeletionProtected(instance Instance) (bool, error) {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return false, err
+	}
+	protected, ok := eng.(ProtectedEngine)
+	if !ok {
+		return false, nil
+	}
+
+	return protected.DeletionProtected(instance.Name)
+}
+
+func setDeletionProtection(eng Engine, name string, enabled bool) error {
+	protected, ok := eng.(ProtectedEngine)
+	if !ok {
+		return errors.New("engine doesn't support deletion protection")
+	}
+
+	return protected.SetDeletionProtection(name, enabled)
+}
+
+// checkDeletionProtection returns ErrDeletionProtected if the cluster is protected from deletion
+func checkDeletionProtection(eng Engine, name string) error {
+	protected, ok := eng.(ProtectedEngine)
+	if !ok {
+		return nil
+	}
+	enabled, err := protected.DeletionProtected(name)
+	if err != nil {
+		return errors.Wrap(err, "check deletion protection")
+	}
+	if enabled {
+		return errors.Wrapf(ErrDeletionProtected, "database %s", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package dbaas
+
+import "github.com/pkg/errors"
+
+// Scale is the requested number of nodes of the cluster
+type Scale struct {
+	// Nodes is the number of the database nodes or members of the replset
+	Nodes int32
+	// Proxies is the number of the proxy nodes, it isn't changed if it is 0
+	Proxies int32
+	// Replset is the name of the scaled replset, the first replset is used if it is empty
+	Replset string
+}
+
+// ScalableEngine is implemented by engines which can scale the cluster with the safety checks
+type ScalableEngine interface {
+	// ScaleDBCluster checks that the cluster can be scaled and applies the scale.
+	// It returns warnings about the checks which couldn't be done
+	ScaleDBCluster(name string, s Scale) ([]string, error)
+	// ClusterMembers returns the number of the members which joined the cluster or the replset
+	ClusterMembers(name, replset string) (int32, error)
+}
+
+// ScaleDB changes the number of nodes of the DB resource. The number of nodes should be odd to keep the quorum
+func ScaleDB(instance Instance, s Scale) ([]string, error) {
+	if s.Nodes < 1 {
+		return nil, errors.Errorf("invalid number of nodes %d: should be greater than 0", s.Nodes)
+	}
+	if s.Nodes%2 == 0 {
+		return nil, errors.Errorf("invalid number of nodes %d: should be odd to keep the quorum if half of the nodes fail, use %d or %d", s.Nodes, s.Nodes-1, s.Nodes+1)
+	}
+	if s.Proxies < 0 {
+		return nil, errors.Errorf("invalid number of proxies %d: should be greater than 0", s.Proxies)
+	}
+	eng, err := scalableEngine(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return eng.ScaleDBCluster(instance.Name, s)
+}
+
+// ClusterMembers returns the number of the members which joined the DB resource cluster or the given replset
+func ClusterMembers(instance Instance, replset string) (int32, error) {
+	eng, err := scalableEngine(instance)
+	if err != nil {
+		return 0, err
+	}
+
+	return eng.ClusterMembers(instance.Name, replset)
+}
+
+func scalableEngine(instance Instance) (ScalableEngine, error) {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return nil, err
+	}
+	scalable, ok := eng.(ScalableEngine)
+	if !ok {
+		return nil, errors.Errorf("engine %s doesn't support scaling", instance.Engine)
+	}
+
+	return scalable, nil
+}
@@ -0,0 +1,172 @@
+package dbaas
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// exposeTypes maps the expose values to the Kubernetes service types
+var exposeTypes = map[string]corev1.ServiceType{
+	"loadbalancer": corev1.ServiceTypeLoadBalancer,
+	"nodeport":     corev1.ServiceTypeNodePort,
+	"clusterip":    corev1.ServiceTypeClusterIP,
+}
+
+// ExposeTypes returns the valid values of the cluster expose setting
+func ExposeTypes() []string {
+	return []string{"loadbalancer", "nodeport", "clusterip"}
+}
+
+// ClusterSettings are the common settings of the cluster which every engine maps to its own options.
+// Empty values are left as they are in the cluster object
+type ClusterSettings struct {
+	// Size is the number of the database nodes
+	Size int
+	// StorageSize is the volume size of every node, e.g. 10G
+	StorageSize string
+	// StorageClass is the storage class of the volumes
+	StorageClass string
+	// CPU is the requested CPU of every node, e.g. 600m
+	CPU string
+	// Memory is the requested memory of every node, e.g. 1G
+	Memory string
+	// CPULimit and MemoryLimit are the resource limits of every node
+	CPULimit    string
+	MemoryLimit string
+	// Expose is the service type the cluster is exposed with: loadbalancer, nodeport or clusterip
+	Expose string
+	// Labels are added to the cluster object and secrets
+	Labels map[string]string
+	// Annotations are added to the cluster object and secrets
+	Annotations map[string]string
+	// TTL is the time from now after which the cluster expires and can be deleted by the garbage collection
+	TTL time.Duration
+	// PMMServer is the host of the PMM server the cluster is monitored with, monitoring is enabled if it is set
+	PMMServer string
+	// PMMUser is the user of the PMM server
+	PMMUser string
+	// Encryption enables data at rest encryption
+	Encryption Encryption
+}
+
+// Validate returns an error if any of the settings is invalid
+func (s ClusterSettings) Validate() error {
+	if s.Size < 0 {
+		return errors.Errorf("invalid size %d: should be greater than 0", s.Size)
+	}
+	err := validateQuantity(s.StorageSize, "storage size", "10G or 500Mi")
+	if err != nil {
+		return err
+	}
+	if len(s.StorageClass) > 0 && len(validation.IsDNS1123Subdomain(s.StorageClass)) > 0 {
+		return errors.Errorf("invalid storage class %q: should consist of lower case alphanumeric characters, '-' or '.'", s.StorageClass)
+	}
+	err = validateQuantity(s.CPU, "CPU", "1 or 500m")
+	if err != nil {
+		return err
+	}
+	err = validateQuantity(s.Memory, "memory", "2G or 512Mi")
+	if err != nil {
+		return err
+	}
+	err = validateQuantity(s.CPULimit, "CPU limit", "1 or 500m")
+	if err != nil {
+		return err
+	}
+	err = validateQuantity(s.MemoryLimit, "memory limit", "2G or 512Mi")
+	if err != nil {
+		return err
+	}
+	if len(s.Expose) > 0 {
+		if _, ok := exposeTypes[strings.ToLower(s.Expose)]; !ok {
+			return errors.Errorf("invalid expose type %q: should be one of %s", s.Expose, strings.Join(ExposeTypes(), ", "))
+		}
+	}
+	if len(s.PMMServer) > 0 && strings.ContainsAny(s.PMMServer, "/, ") {
+		return errors.Errorf("invalid PMM server %q: should be a host name or IP address with an optional port", s.PMMServer)
+	}
+	if s.TTL < 0 {
+		return errors.Errorf("invalid TTL %s: should be greater than 0", s.TTL)
+	}
+	for k, v := range s.Labels {
+		if msgs := validation.IsQualifiedName(k); len(msgs) > 0 {
+			return errors.Errorf("invalid label key %q: %s", k, strings.Join(msgs, "; "))
+		}
+		if msgs := validation.IsValidLabelValue(v); len(msgs) > 0 {
+			return errors.Errorf("invalid label value %q: %s", v, strings.Join(msgs, "; "))
+		}
+	}
+	for k := range s.Annotations {
+		if msgs := validation.IsQualifiedName(strings.ToLower(k)); len(msgs) > 0 {
+			return errors.Errorf("invalid annotation key %q: %s", k, strings.Join(msgs, "; "))
+		}
+	}
+
+	return nil
+}
+
+// ServiceType returns the Kubernetes service type of the expose setting or an empty string if it isn't set
+func (s ClusterSettings) ServiceType() string {
+	return string(exposeTypes[strings.ToLower(s.Expose)])
+}
+
+func validateQuantity(value, name, example string) error {
+	if len(value) == 0 {
+		return nil
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return errors.Errorf("invalid %s %q: use a quantity like %s", name, value, example)
+	}
+	if q.Sign() <= 0 {
+		return errors.Errorf("invalid %s %q: should be greater than 0", name, value)
+	}
+
+	return nil
+}
+
+func (i Instance) settings() ClusterSettings {
+	return ClusterSettings{
+		Size:         i.ClusterSize,
+		StorageSize:  i.DiskSize,
+		StorageClass: i.StorageClass,
+		CPU:          i.CPU,
+		Memory:       i.Memory,
+		Expose:       i.Expose,
+		Labels:       i.Labels,
+		Annotations:  i.Annotations,
+		TTL:          i.TTL,
+		PMMServer:    i.PMMServer,
+		PMMUser:      i.PMMUser,
+		Encryption:   i.Encryption,
+	}
+}
+
+// engineOptions returns the instance engine options with the options of the instance defaults prepended and
+// the options of the instance settings appended, so the settings take precedence over the same options
+// and the options take precedence over the defaults
+func engineOptions(eng Engine, instance Instance) (string, error) {
+	settings := instance.settings()
+	err := settings.Validate()
+	if err != nil {
+		return "", err
+	}
+	err = instance.Defaults.Validate()
+	if err != nil {
+		return "", errors.Wrap(err, "defaults")
+	}
+
+	var opts []string
+	for _, o := range []string{eng.ClusterOptions(instance.Defaults), instance.EngineOptions, eng.ClusterOptions(settings)} {
+		if len(o) > 0 {
+			opts = append(opts, o)
+		}
+	}
+
+	return strings.Join(opts, ","), nil
+}
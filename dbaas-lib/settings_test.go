@@ -0,0 +1,53 @@
+package dbaas_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+func TestClusterSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings dbaas.ClusterSettings
+		wantErr  bool
+	}{
+		{"empty", dbaas.ClusterSettings{}, false},
+		{"valid", dbaas.ClusterSettings{
+			Size:         3,
+			StorageSize:  "10G",
+			StorageClass: "fast-ssd",
+			CPU:          "600m",
+			Memory:       "1Gi",
+			CPULimit:     "1",
+			MemoryLimit:  "2G",
+			Expose:       "LoadBalancer",
+			Labels:       map[string]string{"team": "db", "example.com/env": "dev"},
+			Annotations:  map[string]string{"example.com/Owner": "someone"},
+			TTL:          48 * time.Hour,
+			PMMServer:    "pmm.example.com:443",
+		}, false},
+		{"negative size", dbaas.ClusterSettings{Size: -1}, true},
+		{"invalid storage size", dbaas.ClusterSettings{StorageSize: "10 gigs"}, true},
+		{"zero storage size", dbaas.ClusterSettings{StorageSize: "0"}, true},
+		{"invalid storage class", dbaas.ClusterSettings{StorageClass: "Fast_SSD"}, true},
+		{"invalid cpu", dbaas.ClusterSettings{CPU: "one"}, true},
+		{"negative memory", dbaas.ClusterSettings{Memory: "-1G"}, true},
+		{"invalid cpu limit", dbaas.ClusterSettings{CPULimit: "1 core"}, true},
+		{"invalid expose", dbaas.ClusterSettings{Expose: "ingress"}, true},
+		{"pmm server url", dbaas.ClusterSettings{PMMServer: "https://pmm.example.com"}, true},
+		{"pmm server list", dbaas.ClusterSettings{PMMServer: "pmm1.example.com,pmm2.example.com"}, true},
+		{"negative ttl", dbaas.ClusterSettings{TTL: -time.Hour}, true},
+		{"invalid label key", dbaas.ClusterSettings{Labels: map[string]string{"-team": "db"}}, true},
+		{"invalid label value", dbaas.ClusterSettings{Labels: map[string]string{"team": "db team"}}, true},
+		{"invalid annotation key", dbaas.ClusterSettings{Annotations: map[string]string{"owner name": "someone"}}, true},
+	}
+
+	for _, tt := range tests {
+		err := tt.settings.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package dbaas
+
+import "github.com/pkg/errors"
+
+// Volume resize states
+const (
+	VolumeResizing                = "resizing"
+	VolumeFileSystemResizePending = "filesystem-resize-pending"
+	VolumeResized                 = "resized"
+)
+
+// VolumeResize represents resize state of a data volume of the cluster
+type VolumeResize struct {
+	Name      string `json:"name"`
+	Capacity  string `json:"capacity"`
+	Requested string `json:"requested"`
+	State     string `json:"state"`
+}
+
+// StorageResize is the result of the cluster storage resize
+type StorageResize struct {
+	// Expanded is true if the volumes are expanded in place
+	Expanded bool `json:"expanded"`
+	// Reason is the reason the volumes can't be expanded in place
+	Reason string `json:"reason,omitempty"`
+	// Steps are the manual steps of the volumes rolling replacement if the volumes can't be expanded in place
+	Steps []string `json:"steps,omitempty"`
+}
+
+// StorageEngine is implemented by engines which can resize data volumes of the cluster
+type StorageEngine interface {
+	ResizeStorage(name, size string) (StorageResize, error)
+	VolumesResizeState(name string) ([]VolumeResize, error)
+}
+
+// ResizeStorage resizes data volumes of the DB resource to the given size and sets the size in the DB resource
+func ResizeStorage(instance Instance, size string) (StorageResize, error) {
+	if len(size) == 0 {
+		return StorageResize{}, errors.New("storage size is not set")
+	}
+	err := validateQuantity(size, "storage size", "10G or 500Mi")
+	if err != nil {
+		return StorageResize{}, err
+	}
+	eng, err := storageEngine(instance)
+	if err != nil {
+		return StorageResize{}, err
+	}
+
+	return eng.ResizeStorage(instance.Name, size)
+}
+
+// VolumesResizeState returns resize state of every data volume of the DB resource
+func VolumesResizeState(instance Instance) ([]VolumeResize, error) {
+	eng, err := storageEngine(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return eng.VolumesResizeState(instance.Name)
+}
+
+func storageEngine(instance Instance) (StorageEngine, error) {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return nil, err
+	}
+	storage, ok := eng.(StorageEngine)
+	if !ok {
+		return nil, errors.Errorf("engine %s doesn't support storage resize", instance.Engine)
+	}
+
+	return storage, nil
+}
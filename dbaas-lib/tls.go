@@ -0,0 +1,103 @@
+package dbaas
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TLS modes of the cluster
+const (
+	// TLSGenerate is the mode with a CA and certificates generated by the client
+	TLSGenerate = "generate"
+	// TLSIssuer is the mode with certificates issued by the cert-manager Issuer
+	TLSIssuer = "issuer"
+	// TLSClusterIssuer is the mode with certificates issued by the cert-manager ClusterIssuer
+	TLSClusterIssuer = "cluster-issuer"
+	// TLSSecret is the mode with the certificate and key of an existing secret
+	TLSSecret = "secret"
+)
+
+// TLS is the TLS configuration of the new cluster. The zero value leaves the TLS setup to the operator
+type TLS struct {
+	Mode string
+	// Name is the issuer name of the issuer modes or the secret name of the secret mode
+	Name string
+}
+
+// ParseTLS parses the TLS configuration given as "generate", "issuer=<name>", "cluster-issuer=<name>" or "secret=<name>"
+func ParseTLS(value string) (TLS, error) {
+	if len(value) == 0 {
+		return TLS{}, nil
+	}
+	kv := strings.SplitN(value, "=", 2)
+	tls := TLS{Mode: kv[0]}
+	if len(kv) == 2 {
+		tls.Name = kv[1]
+	}
+	switch tls.Mode {
+	case TLSGenerate:
+		if len(tls.Name) > 0 {
+			return TLS{}, errors.Errorf("invalid TLS %q: generate mode has no value", value)
+		}
+	case TLSIssuer, TLSClusterIssuer, TLSSecret:
+		if len(tls.Name) == 0 {
+			return TLS{}, errors.Errorf("invalid TLS %q: use %s=<name>", value, tls.Mode)
+		}
+	default:
+		return TLS{}, errors.Errorf("invalid TLS %q: use generate, issuer=<name>, cluster-issuer=<name> or secret=<name>", value)
+	}
+
+	return tls, nil
+}
+
+// Encryption is the data at rest encryption configuration of the new cluster
+type Encryption struct {
+	Enabled bool
+	// KeySecret is the secret with the encryption key or the key storage access. Engines which
+	// generate the key don't require it
+	KeySecret string
+}
+
+// SecureEngine is implemented by engines which can set up TLS and data at rest encryption of the new cluster
+type SecureEngine interface {
+	// SetupSecurity creates the TLS secrets of the cluster with the given options and version and checks
+	// the encryption requirements. It is called before the cluster is created
+	SetupSecurity(name, opts, version string, tls TLS, encryption Encryption) error
+	// DeleteSecurity deletes the TLS secrets and certificates created by SetupSecurity.
+	// It is called if the cluster creation fails
+	DeleteSecurity(name string, tls TLS) error
+}
+
+func setupSecurity(eng Engine, instance Instance, opts string) error {
+	if len(instance.TLS.Mode) == 0 && !instance.Encryption.Enabled {
+		return nil
+	}
+	secure, ok := eng.(SecureEngine)
+	if !ok {
+		return errors.Errorf("engine %s doesn't support TLS and encryption setup", instance.Engine)
+	}
+	err := secure.SetupSecurity(instance.Name, opts, instance.Version, instance.TLS, instance.Encryption)
+	if err != nil {
+		return errors.Wrap(err, "setup security")
+	}
+
+	return nil
+}
+
+// cleanupSecurity deletes the TLS secrets of the cluster which creation failed with the given error
+func cleanupSecurity(eng Engine, instance Instance, err error) error {
+	if len(instance.TLS.Mode) == 0 {
+		return err
+	}
+	secure, ok := eng.(SecureEngine)
+	if !ok {
+		return err
+	}
+	derr := secure.DeleteSecurity(instance.Name, instance.TLS)
+	if derr != nil {
+		return errors.Errorf("%v; delete TLS secrets: %v", err, derr)
+	}
+
+	return err
+}
@@ -0,0 +1,37 @@
+package dbaas_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Percona-Lab/percona-dbaas-cli/dbaas-lib"
+)
+
+func TestParseTLS(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    dbaas.TLS
+		wantErr bool
+	}{
+		{"", dbaas.TLS{}, false},
+		{"generate", dbaas.TLS{Mode: dbaas.TLSGenerate}, false},
+		{"issuer=ca-issuer", dbaas.TLS{Mode: dbaas.TLSIssuer, Name: "ca-issuer"}, false},
+		{"cluster-issuer=letsencrypt", dbaas.TLS{Mode: dbaas.TLSClusterIssuer, Name: "letsencrypt"}, false},
+		{"secret=db-tls", dbaas.TLS{Mode: dbaas.TLSSecret, Name: "db-tls"}, false},
+		{"generate=ca", dbaas.TLS{}, true},
+		{"issuer", dbaas.TLS{}, true},
+		{"secret=", dbaas.TLS{}, true},
+		{"acme=letsencrypt", dbaas.TLS{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := dbaas.ParseTLS(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: got error %v, want error %t", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("got %+v, want %+v", got, tt.want)
+		}
+	}
+}
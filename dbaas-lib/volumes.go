@@ -0,0 +1,91 @@
+package dbaas
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Volume is a data volume preserved after deletion of its cluster
+type Volume struct {
+	Name         string    `json:"name"`
+	Cluster      string    `json:"cluster"`
+	Engine       string    `json:"engine"`
+	Provider     string    `json:"provider"`
+	Component    string    `json:"component,omitempty"`
+	Replset      string    `json:"replset,omitempty"`
+	Size         string    `json:"size,omitempty"`
+	StorageClass string    `json:"storageClass,omitempty"`
+	Created      time.Time `json:"created"`
+}
+
+// VolumeEngine is implemented by engines which manage data volumes preserved after the cluster deletion
+type VolumeEngine interface {
+	// OrphanedVolumes returns the data volumes of the engine clusters which don't exist anymore
+	OrphanedVolumes() ([]Volume, error)
+	// AttachVolumes creates the cluster with the given name on top of its preserved volumes
+	AttachVolumes(name, opts, version string) error
+	// PurgeVolumes deletes the preserved volumes and secrets of the deleted cluster
+	PurgeVolumes(name string) error
+}
+
+// ListOrphanedVolumes returns the data volumes of the deleted clusters of every registered provider and engine in the given environment
+func ListOrphanedVolumes(env Environment) ([]Volume, error) {
+	var list []Volume
+	for _, providerName := range sortedKeys(Providers) {
+		for _, engineName := range engineNames(providerName) {
+			eng, err := setupEngine(providerName, engineName, env)
+			if err != nil {
+				return nil, err
+			}
+			volEng, ok := eng.(VolumeEngine)
+			if !ok {
+				continue
+			}
+			volumes, err := volEng.OrphanedVolumes()
+			if err != nil {
+				return nil, errors.Wrapf(err, "list %s/%s volumes", providerName, engineName)
+			}
+			for i := range volumes {
+				volumes[i].Engine = engineName
+				volumes[i].Provider = providerName
+			}
+			list = append(list, volumes...)
+		}
+	}
+
+	return list, nil
+}
+
+// AttachVolumes creates the instance DB resource on top of its preserved volumes with the instance options and version
+func AttachVolumes(instance Instance) error {
+	eng, err := volumeEngine(instance)
+	if err != nil {
+		return err
+	}
+
+	return eng.AttachVolumes(instance.Name, instance.EngineOptions, instance.Version)
+}
+
+// PurgeVolumes deletes the preserved volumes of the deleted instance DB resource
+func PurgeVolumes(instance Instance) error {
+	eng, err := volumeEngine(instance)
+	if err != nil {
+		return err
+	}
+
+	return eng.PurgeVolumes(instance.Name)
+}
+
+func volumeEngine(instance Instance) (VolumeEngine, error) {
+	eng, err := getEngine(instance)
+	if err != nil {
+		return nil, err
+	}
+	volEng, ok := eng.(VolumeEngine)
+	if !ok {
+		return nil, errors.Errorf("engine %s doesn't support preserved volumes", instance.Engine)
+	}
+
+	return volEng, nil
+}
@@ -22,8 +22,10 @@ require (
 	github.com/hashicorp/go-version v1.2.0 // indirect
 	github.com/pkg/errors v0.8.1
 	github.com/sirupsen/logrus v1.4.2
-	github.com/spf13/cobra v0.0.5
+	github.com/spf13/cobra v1.1.1
+	github.com/spf13/pflag v1.0.5
 	k8s.io/api v0.17.0
 	k8s.io/apimachinery v0.17.0
 	sigs.k8s.io/controller-runtime v0.4.0 // indirect
+	sigs.k8s.io/yaml v1.1.0
 )